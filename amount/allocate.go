@@ -0,0 +1,95 @@
+package amount
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+)
+
+// ErrInvalidWeights indicates weights which are negative or sum to zero.
+var ErrInvalidWeights = errors.New("weights must be non-negative with a positive sum")
+
+// Allocate splits the amount into parts proportional to the weights, using the
+// largest remainder method: every part is first rounded down, then the sats
+// left over are given one at a time to the parts with the largest remainders.
+// Ties go to the part with the lowest index, so the result is deterministic.
+//
+// The parts always sum exactly to the amount, and a part with zero weight is
+// always zero.  A negative amount is split as its absolute value and every
+// part negated.
+func (a AmountType) Allocate(weights ...uint64) ([]AmountType, error) {
+	w := make([]*big.Int, len(weights))
+	for i, weight := range weights {
+		w[i] = new(big.Int).SetUint64(weight)
+	}
+	return a.allocate(w)
+}
+
+// AllocateRatios splits the amount like Allocate, with the weights given as
+// exact ratios, such as 1/3 and 2/3.  The ratios need not sum to one.
+func (a AmountType) AllocateRatios(ratios ...*big.Rat) ([]AmountType, error) {
+	// Scale the ratios by the least common multiple of their denominators
+	// to get integer weights.
+	lcm := big.NewInt(1)
+	gcd := new(big.Int)
+	for _, r := range ratios {
+		if r.Sign() < 0 {
+			return nil, ErrInvalidWeights
+		}
+		gcd.GCD(nil, nil, lcm, r.Denom())
+		lcm.Mul(lcm, new(big.Int).Quo(r.Denom(), gcd))
+	}
+
+	w := make([]*big.Int, len(ratios))
+	for i, r := range ratios {
+		w[i] = new(big.Int).Mul(r.Num(), new(big.Int).Quo(lcm, r.Denom()))
+	}
+	return a.allocate(w)
+}
+
+func (a AmountType) allocate(weights []*big.Int) ([]AmountType, error) {
+	total := new(big.Int)
+	for _, w := range weights {
+		if w.Sign() < 0 {
+			return nil, ErrInvalidWeights
+		}
+		total.Add(total, w)
+	}
+	if total.Sign() == 0 {
+		return nil, ErrInvalidWeights
+	}
+
+	amount := big.NewInt(int64(a))
+	neg := amount.Sign() < 0
+	amount.Abs(amount)
+
+	parts := make([]*big.Int, len(weights))
+	remainders := make([]*big.Int, len(weights))
+	left := new(big.Int).Set(amount)
+	for i, w := range weights {
+		parts[i], remainders[i] = new(big.Int).QuoRem(new(big.Int).Mul(amount, w), total, new(big.Int))
+		left.Sub(left, parts[i])
+	}
+
+	// Fewer sats than parts are left over, one for each of the largest
+	// remainders.
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]].Cmp(remainders[order[j]]) > 0
+	})
+	for _, i := range order[:left.Int64()] {
+		parts[i].Add(parts[i], big.NewInt(1))
+	}
+
+	result := make([]AmountType, len(parts))
+	for i, p := range parts {
+		if neg {
+			p.Neg(p)
+		}
+		result[i] = AmountType(p.Int64())
+	}
+	return result, nil
+}
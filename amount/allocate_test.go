@@ -0,0 +1,138 @@
+package amount
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestAmountAllocate(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   AmountType
+		weights  []uint64
+		expected []AmountType
+		err      error
+	}{
+		{
+			name:     "even",
+			amount:   100,
+			weights:  []uint64{1, 1, 1, 1},
+			expected: []AmountType{25, 25, 25, 25},
+		},
+		{
+			name:     "thirds",
+			amount:   100,
+			weights:  []uint64{1, 1, 1},
+			expected: []AmountType{34, 33, 33},
+		},
+		{
+			name:     "largest remainder",
+			amount:   10,
+			weights:  []uint64{1, 3, 2},
+			expected: []AmountType{2, 5, 3},
+		},
+		{
+			name:     "ties go to the lowest index",
+			amount:   5,
+			weights:  []uint64{2, 1, 1, 2},
+			expected: []AmountType{2, 1, 1, 1},
+		},
+		{
+			name:     "zero weight",
+			amount:   7,
+			weights:  []uint64{0, 1, 0, 1},
+			expected: []AmountType{0, 4, 0, 3},
+		},
+		{
+			name:     "negative",
+			amount:   -100,
+			weights:  []uint64{1, 1, 1},
+			expected: []AmountType{-34, -33, -33},
+		},
+		{
+			name:     "zero amount",
+			amount:   0,
+			weights:  []uint64{1, 2},
+			expected: []AmountType{0, 0},
+		},
+		{
+			name:     "large weights",
+			amount:   math.MaxInt64,
+			weights:  []uint64{math.MaxUint64, math.MaxUint64},
+			expected: []AmountType{math.MaxInt64/2 + 1, math.MaxInt64 / 2},
+		},
+		{
+			name:     "min int64",
+			amount:   math.MinInt64,
+			weights:  []uint64{1},
+			expected: []AmountType{math.MinInt64},
+		},
+		{
+			name:    "no weights",
+			amount:  100,
+			weights: nil,
+			err:     ErrInvalidWeights,
+		},
+		{
+			name:    "zero weights",
+			amount:  100,
+			weights: []uint64{0, 0},
+			err:     ErrInvalidWeights,
+		},
+	}
+
+	for _, test := range tests {
+		parts, err := test.amount.Allocate(test.weights...)
+		if err != test.err {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+			continue
+		}
+		if !reflect.DeepEqual(parts, test.expected) {
+			t.Errorf("%v: expected %v, got %v", test.name, test.expected, parts)
+		}
+	}
+}
+
+func TestAmountAllocateSum(t *testing.T) {
+	weights := []uint64{7, 13, 1, 0, 29, 3, 3, 11}
+	for _, a := range []AmountType{1, 99, 12345678, MaxSats, -987654321} {
+		parts, err := a.Allocate(weights...)
+		if err != nil {
+			t.Fatalf("%d: unexpected error %v", a, err)
+		}
+		var total AmountType
+		for _, p := range parts {
+			total += p
+		}
+		if total != a {
+			t.Errorf("%d: parts %v sum to %d", a, parts, total)
+		}
+	}
+}
+
+func TestAmountAllocateRatios(t *testing.T) {
+	parts, err := AmountType(1e8).AllocateRatios(big.NewRat(1, 3), big.NewRat(1, 2), big.NewRat(1, 6))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected := []AmountType{33333333, 50000000, 16666667}
+	if !reflect.DeepEqual(parts, expected) {
+		t.Errorf("expected %v, got %v", expected, parts)
+	}
+
+	// The ratios are relative to each other.
+	parts, err = AmountType(10).AllocateRatios(big.NewRat(3, 2), big.NewRat(1, 2))
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected = []AmountType{8, 2}
+	if !reflect.DeepEqual(parts, expected) {
+		t.Errorf("expected %v, got %v", expected, parts)
+	}
+
+	if _, err := AmountType(10).AllocateRatios(big.NewRat(1, 2), big.NewRat(-1, 2)); err != ErrInvalidWeights {
+		t.Errorf("expected ErrInvalidWeights, got %v", err)
+	}
+}
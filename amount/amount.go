@@ -4,11 +4,7 @@
 
 package amount
 
-import (
-	"errors"
-	"math"
-	"strconv"
-)
+import "math/big"
 
 type AmountUnit int
 
@@ -33,35 +29,50 @@ func round(f float64) AmountType {
 	return AmountType(f + 0.5)
 }
 
+// NewAmount converts a floating point number of units to an amount, rounded to
+// the nearest sat.  An error is returned for NaN and infinities, but the
+// amount isn't checked against the money range: call Validate on the result,
+// or use ParseAmount to convert exact decimal strings.
 func NewAmount(f float64) (AmountType, error) {
-	switch {
-	case math.IsNaN(f):
-		fallthrough
-	case math.IsInf(f, 1):
-		fallthrough
-	case math.IsInf(f, -1):
-		return 0, errors.New("invalid amount")
-	}
-
-	return round(f * SatsPerUnit), nil
+	return DefaultDenomination.NewAmount(f)
 }
 
+// ToUnit converts the amount to the given unit, rounded to the nearest
+// float64.
 func (a AmountType) ToUnit(u AmountUnit) float64 {
-	return float64(a) / math.Pow10(int(u+8))
+	return DefaultDenomination.ToUnit(a, u)
+}
+
+// ToUnitRat converts the amount to the given unit exactly.
+func (a AmountType) ToUnitRat(u AmountUnit) *big.Rat {
+	return DefaultDenomination.ToUnitRat(a, u)
 }
 
 func (a AmountType) ToNormalUnit() float64 {
 	return a.ToUnit(Amount)
 }
 
+// Format formats the exact value of the amount in the given unit, without
+// trailing zeros.
 func (a AmountType) Format(u AmountUnit) string {
-	return strconv.FormatFloat(a.ToUnit(u), 'f', -int(u+8), 64)
+	return DefaultDenomination.Format(a, u)
 }
 
 func (a AmountType) String() string {
 	return a.Format(Amount)
 }
 
+// MulF64 multiplies the amount by a floating point number, rounded to the
+// nearest sat.  The result is neither checked for overflow nor against the
+// money range; use MulFloat, or Mul for integers, when it must be.
 func (a AmountType) MulF64(f float64) AmountType {
 	return round(float64(a) * f)
 }
+
+// MulFloat multiplies the amount by a floating point number like MulF64, but
+// returns an error when f is not finite, ErrOverflow when the product doesn't
+// fit in an int64 and ErrMoneyRange when the amount or the result is not
+// valid money.
+func (a AmountType) MulFloat(f float64) (AmountType, error) {
+	return DefaultDenomination.MulFloat(a, f)
+}
@@ -290,3 +290,59 @@ func TestAmountMulF64(t *testing.T) {
 		}
 	}
 }
+
+func TestAmountExactConversions(t *testing.T) {
+	tests := []struct {
+		amount AmountType
+		unit   AmountUnit
+		rat    string
+		s      string
+	}{
+		{
+			amount: math.MaxInt64,
+			unit:   AmountSats,
+			rat:    "9223372036854775807/1",
+			s:      "9223372036854775807",
+		},
+		{
+			amount: math.MinInt64,
+			unit:   Amount,
+			rat:    "-36028797018963968/390625",
+			s:      "-92233720368.54775808",
+		},
+		{
+			amount: 30000000,
+			unit:   Amount,
+			rat:    "3/10",
+			s:      "0.3",
+		},
+		{
+			amount: 1,
+			unit:   AmountMega,
+			rat:    "1/100000000000000",
+			s:      "0.00000000000001",
+		},
+		{
+			amount: 123,
+			unit:   AmountUnit(-10),
+			rat:    "12300/1",
+			s:      "12300",
+		},
+	}
+
+	for _, test := range tests {
+		r := test.amount.ToUnitRat(test.unit)
+		if r.String() != test.rat {
+			t.Errorf("%d: expected rat %v, got %v", test.amount, test.rat, r)
+		}
+
+		f, _ := r.Float64()
+		if test.amount.ToUnit(test.unit) != f {
+			t.Errorf("%d: ToUnit %v is not the nearest float64 %v", test.amount, test.amount.ToUnit(test.unit), f)
+		}
+
+		if s := test.amount.Format(test.unit); s != test.s {
+			t.Errorf("%d: format %q does not match expected %q", test.amount, s, test.s)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package amount
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	// ErrOverflow indicates an operation overflowed an int64.
+	ErrOverflow = errors.New("amount overflows int64")
+
+	// ErrMoneyRange indicates an amount outside the range allowed by the max
+	// supply of the denomination.
+	ErrMoneyRange = errors.New("amount outside of money range")
+
+	// ErrDivideByZero indicates a division by zero.
+	ErrDivideByZero = errors.New("amount divided by zero")
+)
+
+// IsValidMoney returns whether or not the amount is within
+// [-MaxSats, MaxSats].
+func (a AmountType) IsValidMoney() bool {
+	return DefaultDenomination.IsValidMoney(a)
+}
+
+// Validate returns ErrMoneyRange when the amount is outside
+// [-MaxSats, MaxSats], and nil otherwise.
+func (a AmountType) Validate() error {
+	return DefaultDenomination.ValidateAmount(a)
+}
+
+// Add returns a + b.  Both the operands and the result must be valid money.
+func (a AmountType) Add(b AmountType) (AmountType, error) {
+	return DefaultDenomination.Add(a, b)
+}
+
+// Sub returns a - b.  Both the operands and the result must be valid money.
+func (a AmountType) Sub(b AmountType) (AmountType, error) {
+	return DefaultDenomination.Sub(a, b)
+}
+
+// Mul returns a * n.  The amount and the result must be valid money.
+func (a AmountType) Mul(n int64) (AmountType, error) {
+	return DefaultDenomination.Mul(a, n)
+}
+
+// Div returns a / n truncated toward zero.  The amount must be valid money.
+func (a AmountType) Div(n int64) (AmountType, error) {
+	return DefaultDenomination.Div(a, n)
+}
+
+// Sum returns the sum of the amounts.  Every amount and every running total
+// must be valid money, the same way consensus code checks the outputs of a
+// transaction.
+func Sum(amounts ...AmountType) (AmountType, error) {
+	return DefaultDenomination.Sum(amounts...)
+}
+
+// IsValidMoney returns whether or not the amount is within
+// [-MaxSupply, MaxSupply].
+func (d *Denomination) IsValidMoney(a AmountType) bool {
+	return a >= -d.MaxSupply && a <= d.MaxSupply
+}
+
+// ValidateAmount returns ErrMoneyRange when the amount is outside
+// [-MaxSupply, MaxSupply], and nil otherwise.
+func (d *Denomination) ValidateAmount(a AmountType) error {
+	if !d.IsValidMoney(a) {
+		return ErrMoneyRange
+	}
+	return nil
+}
+
+// validate checks the operands of an operation.
+func (d *Denomination) validate(amounts ...AmountType) error {
+	for _, a := range amounts {
+		if err := d.ValidateAmount(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checked returns the result of an operation if it is valid money.
+func (d *Denomination) checked(r AmountType) (AmountType, error) {
+	if err := d.ValidateAmount(r); err != nil {
+		return 0, err
+	}
+	return r, nil
+}
+
+// Add returns a + b.  Both the operands and the result must be valid money.
+func (d *Denomination) Add(a, b AmountType) (AmountType, error) {
+	if err := d.validate(a, b); err != nil {
+		return 0, err
+	}
+	r := a + b
+	if (b > 0 && r < a) || (b < 0 && r > a) {
+		return 0, ErrOverflow
+	}
+	return d.checked(r)
+}
+
+// Sub returns a - b.  Both the operands and the result must be valid money.
+func (d *Denomination) Sub(a, b AmountType) (AmountType, error) {
+	if err := d.validate(a, b); err != nil {
+		return 0, err
+	}
+	r := a - b
+	if (b > 0 && r > a) || (b < 0 && r < a) {
+		return 0, ErrOverflow
+	}
+	return d.checked(r)
+}
+
+// Mul returns a * n.  The amount and the result must be valid money.
+func (d *Denomination) Mul(a AmountType, n int64) (AmountType, error) {
+	if err := d.ValidateAmount(a); err != nil {
+		return 0, err
+	}
+	r := int64(a) * n
+	if n != 0 && r/n != int64(a) {
+		return 0, ErrOverflow
+	}
+	return d.checked(AmountType(r))
+}
+
+// MulFloat returns a * f rounded to the nearest sat.  The amount and the result
+// must be valid money.
+func (d *Denomination) MulFloat(a AmountType, f float64) (AmountType, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, ErrInvalidAmount
+	}
+	if err := d.ValidateAmount(a); err != nil {
+		return 0, err
+	}
+	// Every float64 below 2^63 in magnitude rounds to an int64, since
+	// their spacing there is far larger than one.
+	r := float64(a) * f
+	if r >= -math.MinInt64 || r < math.MinInt64 {
+		return 0, ErrOverflow
+	}
+	return d.checked(round(r))
+}
+
+// Div returns a / n truncated toward zero.  The amount must be valid money.
+func (d *Denomination) Div(a AmountType, n int64) (AmountType, error) {
+	if err := d.ValidateAmount(a); err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, ErrDivideByZero
+	}
+	// A valid amount divided by any n other than zero is valid money.
+	return a / AmountType(n), nil
+}
+
+// Sum returns the sum of the amounts, checking every amount and every running
+// total like Add.
+func (d *Denomination) Sum(amounts ...AmountType) (AmountType, error) {
+	var total AmountType
+	for _, a := range amounts {
+		var err error
+		if total, err = d.Add(total, a); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,191 @@
+package amount
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAmountValidate(t *testing.T) {
+	tests := []struct {
+		amount AmountType
+		valid  bool
+	}{
+		{0, true},
+		{1, true},
+		{-1, true},
+		{MaxSats, true},
+		{-MaxSats, true},
+		{MaxSats + 1, false},
+		{-MaxSats - 1, false},
+		{math.MaxInt64, false},
+		{math.MinInt64, false},
+	}
+
+	for _, test := range tests {
+		if valid := test.amount.IsValidMoney(); valid != test.valid {
+			t.Errorf("%d: expected IsValidMoney %v, got %v", test.amount, test.valid, valid)
+		}
+		err := test.amount.Validate()
+		if test.valid && err != nil {
+			t.Errorf("%d: unexpected error %v", test.amount, err)
+		}
+		if !test.valid && err != ErrMoneyRange {
+			t.Errorf("%d: expected ErrMoneyRange, got %v", test.amount, err)
+		}
+	}
+
+	// NewAmount doesn't enforce the range, so its result must be validated.
+	a, err := NewAmount(21e6 + 1e-8)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if a.Validate() != ErrMoneyRange {
+		t.Errorf("expected ErrMoneyRange for %d", a)
+	}
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func() (AmountType, error)
+		expected AmountType
+		err      error
+	}{
+		{
+			name:     "add",
+			op:       func() (AmountType, error) { return AmountType(1e8).Add(5) },
+			expected: 1e8 + 5,
+		},
+		{
+			name:     "add to max",
+			op:       func() (AmountType, error) { return AmountType(MaxSats - 1).Add(1) },
+			expected: MaxSats,
+		},
+		{
+			name: "add beyond max",
+			op:   func() (AmountType, error) { return AmountType(MaxSats).Add(1) },
+			err:  ErrMoneyRange,
+		},
+		{
+			name: "add invalid operand",
+			op:   func() (AmountType, error) { return AmountType(math.MaxInt64).Add(-math.MaxInt64) },
+			err:  ErrMoneyRange,
+		},
+		{
+			name:     "sub",
+			op:       func() (AmountType, error) { return AmountType(5).Sub(7) },
+			expected: -2,
+		},
+		{
+			name: "sub beyond min",
+			op:   func() (AmountType, error) { return AmountType(-MaxSats).Sub(1) },
+			err:  ErrMoneyRange,
+		},
+		{
+			name: "sub max from min",
+			op:   func() (AmountType, error) { return AmountType(-MaxSats).Sub(MaxSats) },
+			err:  ErrMoneyRange,
+		},
+		{
+			name:     "mul",
+			op:       func() (AmountType, error) { return AmountType(-3).Mul(7) },
+			expected: -21,
+		},
+		{
+			name:     "mul by zero",
+			op:       func() (AmountType, error) { return AmountType(MaxSats).Mul(0) },
+			expected: 0,
+		},
+		{
+			name: "mul beyond max",
+			op:   func() (AmountType, error) { return AmountType(MaxSats).Mul(2) },
+			err:  ErrMoneyRange,
+		},
+		{
+			name: "mul overflow",
+			op:   func() (AmountType, error) { return AmountType(1e8).Mul(1e12) },
+			err:  ErrOverflow,
+		},
+		{
+			name: "mul min int64",
+			op:   func() (AmountType, error) { return AmountType(-1).Mul(math.MinInt64) },
+			err:  ErrOverflow,
+		},
+		{
+			name:     "mul float",
+			op:       func() (AmountType, error) { return AmountType(100).MulFloat(2.0 / 3) },
+			expected: 67,
+		},
+		{
+			name: "mul float beyond max",
+			op:   func() (AmountType, error) { return AmountType(MaxSats).MulFloat(1.5) },
+			err:  ErrMoneyRange,
+		},
+		{
+			name: "mul float overflow",
+			op:   func() (AmountType, error) { return AmountType(MaxSats).MulFloat(1e10) },
+			err:  ErrOverflow,
+		},
+		{
+			name: "mul float negative overflow",
+			op:   func() (AmountType, error) { return AmountType(MaxSats).MulFloat(-1e10) },
+			err:  ErrOverflow,
+		},
+		{
+			name: "mul float nan",
+			op:   func() (AmountType, error) { return AmountType(1).MulFloat(math.NaN()) },
+			err:  ErrInvalidAmount,
+		},
+		{
+			name: "mul float infinity",
+			op:   func() (AmountType, error) { return AmountType(0).MulFloat(math.Inf(1)) },
+			err:  ErrInvalidAmount,
+		},
+		{
+			name:     "div",
+			op:       func() (AmountType, error) { return AmountType(-7).Div(2) },
+			expected: -3,
+		},
+		{
+			name: "div by zero",
+			op:   func() (AmountType, error) { return AmountType(1).Div(0) },
+			err:  ErrDivideByZero,
+		},
+		{
+			name: "div invalid amount",
+			op:   func() (AmountType, error) { return AmountType(math.MinInt64).Div(-1) },
+			err:  ErrMoneyRange,
+		},
+		{
+			name:     "sum",
+			op:       func() (AmountType, error) { return Sum(1, 2, 3, -4) },
+			expected: 2,
+		},
+		{
+			name:     "sum of nothing",
+			op:       func() (AmountType, error) { return Sum() },
+			expected: 0,
+		},
+		{
+			name:     "sum to max",
+			op:       func() (AmountType, error) { return Sum(MaxSats/2, MaxSats/2) },
+			expected: MaxSats,
+		},
+		{
+			name: "sum beyond max",
+			op:   func() (AmountType, error) { return Sum(MaxSats, 1, -1) },
+			err:  ErrMoneyRange,
+		},
+	}
+
+	for _, test := range tests {
+		a, err := test.op()
+		if err != test.err {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+			continue
+		}
+		if a != test.expected {
+			t.Errorf("%v: expected %d, got %d", test.name, test.expected, a)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package amount
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+// MaxDecimals is the largest number of decimals a denomination can have, so
+// that one unit fits in an int64.
+const MaxDecimals = 18
+
+var (
+	// ErrInvalidDenomination indicates a denomination with an unsupported
+	// number of decimals or a max supply which isn't positive.
+	ErrInvalidDenomination = errors.New("invalid denomination")
+
+	// ErrInvalidAmount indicates a floating point amount or factor which is
+	// NaN or infinite.
+	ErrInvalidAmount = errors.New("invalid amount")
+)
+
+// Denomination describes how a coin is divided into sats.  Amounts are always
+// counted in sats; the denomination gives them a meaning when they are
+// converted, formatted, parsed and checked against the max supply.
+type Denomination struct {
+	// Decimals is the number of decimals of the base unit, so one unit is
+	// 10^Decimals sats.
+	Decimals int
+
+	// MaxSupply is the largest valid amount in sats.
+	MaxSupply AmountType
+
+	// Names are the names of the units.  Nil means DefaultUnitNames.
+	Names *UnitNames
+}
+
+// DefaultDenomination is the denomination used by the package level functions
+// and the methods of AmountType.  It must not be modified.
+var DefaultDenomination = &Denomination{
+	Decimals:  8,
+	MaxSupply: MaxSats,
+	Names:     DefaultUnitNames,
+}
+
+// Validate returns ErrInvalidDenomination when the number of decimals is
+// outside [0, MaxDecimals] or the max supply isn't positive.
+func (d *Denomination) Validate() error {
+	if d.Decimals < 0 || d.Decimals > MaxDecimals || d.MaxSupply <= 0 {
+		return ErrInvalidDenomination
+	}
+	return nil
+}
+
+// SatsPerUnit returns the number of sats in one base unit.
+func (d *Denomination) SatsPerUnit() AmountType {
+	return AmountType(pow10(int64(d.Decimals)).Int64())
+}
+
+// SatsUnit returns the unit of a single sat, which is AmountSats for eight
+// decimals.
+func (d *Denomination) SatsUnit() AmountUnit {
+	return AmountUnit(-d.Decimals)
+}
+
+// UnitName returns the name of a unit.  It is the name given by the UnitNames
+// of the denomination, except that the smallest unit is always named after
+// sats.
+func (d *Denomination) UnitName(u AmountUnit) string {
+	return d.unitName(nil, u)
+}
+
+// unitName returns the name of a unit using names when not nil.
+func (d *Denomination) unitName(names *UnitNames, u AmountUnit) string {
+	if names == nil {
+		names = d.names()
+	}
+	switch {
+	case u == d.SatsUnit():
+		return names.Sats
+	case u == AmountSats:
+		// Sats are not the smallest unit of this denomination.
+		return names.powerName(u)
+	}
+	return names.Name(u)
+}
+
+func (d *Denomination) names() *UnitNames {
+	if d.Names != nil {
+		return d.Names
+	}
+	return DefaultUnitNames
+}
+
+// NewAmount converts a floating point number of base units to an amount,
+// rounded to the nearest sat.  The amount isn't checked against the max
+// supply; call ValidateAmount on the result.
+func (d *Denomination) NewAmount(f float64) (AmountType, error) {
+	switch {
+	case math.IsNaN(f):
+		fallthrough
+	case math.IsInf(f, 1):
+		fallthrough
+	case math.IsInf(f, -1):
+		return 0, ErrInvalidAmount
+	}
+
+	return round(f * math.Pow10(d.Decimals)), nil
+}
+
+// ToUnit converts the amount to the given unit, rounded to the nearest
+// float64.
+func (d *Denomination) ToUnit(a AmountType, u AmountUnit) float64 {
+	f, _ := d.ToUnitRat(a, u).Float64()
+	return f
+}
+
+// ToUnitRat converts the amount to the given unit exactly.
+func (d *Denomination) ToUnitRat(a AmountType, u AmountUnit) *big.Rat {
+	r := new(big.Rat).SetInt64(int64(a))
+	exp := int64(u) + int64(d.Decimals)
+	if exp < 0 {
+		return r.Mul(r, new(big.Rat).SetInt(pow10(-exp)))
+	}
+	return r.Quo(r, new(big.Rat).SetInt(pow10(exp)))
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
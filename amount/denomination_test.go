@@ -0,0 +1,128 @@
+package amount
+
+import (
+	"errors"
+	"testing"
+)
+
+// sidechain is a denomination with fewer decimals and another supply.
+var sidechain = &Denomination{
+	Decimals:  6,
+	MaxSupply: 1e12 * 1e6,
+	Names:     &UnitNames{Ticker: "SIDE", Sats: "Grains"},
+}
+
+func TestDenominationValidate(t *testing.T) {
+	tests := []struct {
+		d     Denomination
+		valid bool
+	}{
+		{*DefaultDenomination, true},
+		{*sidechain, true},
+		{Denomination{Decimals: 0, MaxSupply: 1}, true},
+		{Denomination{Decimals: MaxDecimals, MaxSupply: 1}, true},
+		{Denomination{Decimals: -1, MaxSupply: 1}, false},
+		{Denomination{Decimals: MaxDecimals + 1, MaxSupply: 1}, false},
+		{Denomination{Decimals: 8, MaxSupply: 0}, false},
+	}
+
+	for i, test := range tests {
+		err := test.d.Validate()
+		if test.valid && err != nil {
+			t.Errorf("%d: unexpected error %v", i, err)
+		}
+		if !test.valid && err != ErrInvalidDenomination {
+			t.Errorf("%d: expected ErrInvalidDenomination, got %v", i, err)
+		}
+	}
+}
+
+func TestDefaultDenomination(t *testing.T) {
+	d := DefaultDenomination
+	if d.SatsPerUnit() != SatsPerUnit {
+		t.Errorf("expected %v sats per unit, got %v", SatsPerUnit, d.SatsPerUnit())
+	}
+	if d.SatsUnit() != AmountSats {
+		t.Errorf("expected AmountSats, got %d", d.SatsUnit())
+	}
+	for _, u := range []AmountUnit{AmountMega, AmountKilo, Amount, AmountMilli, AmountMicro, AmountSats, -1} {
+		if d.UnitName(u) != u.String() {
+			t.Errorf("%d: unit name %q does not match %q", u, d.UnitName(u), u.String())
+		}
+	}
+	if !d.IsValidMoney(MaxSats) || d.IsValidMoney(MaxSats+1) {
+		t.Errorf("default denomination does not enforce MaxSats")
+	}
+}
+
+func TestDenominationConversions(t *testing.T) {
+	d := sidechain
+	if d.SatsPerUnit() != 1e6 {
+		t.Errorf("expected 1e6 sats per unit, got %v", d.SatsPerUnit())
+	}
+
+	a, err := d.NewAmount(1.5)
+	if err != nil || a != 1500000 {
+		t.Errorf("expected 1500000, got %d (%v)", a, err)
+	}
+	if f := d.ToUnit(a, Amount); f != 1.5 {
+		t.Errorf("expected 1.5, got %v", f)
+	}
+	if r := d.ToUnitRat(a, AmountKilo); r.String() != "3/2000" {
+		t.Errorf("expected 3/2000, got %v", r)
+	}
+
+	tests := []struct {
+		amount AmountType
+		unit   AmountUnit
+		s      string
+	}{
+		{1500000, Amount, "1.5 SIDE"},
+		{1, Amount, "0.000001 SIDE"},
+		{1, AmountMicro, "1 Grains"},
+		{1500000, AmountMilli, "1500 mSIDE"},
+		{1, AmountSats, "100 1e-8 SIDE"},
+	}
+	for _, test := range tests {
+		s := d.FormatUnit(test.amount, test.unit)
+		if s != test.s {
+			t.Errorf("%d: expected %q, got %q", test.amount, test.s, s)
+			continue
+		}
+		parsed, err := d.ParseAmountWith(s, FormatOptions{Unit: test.unit, Suffix: true})
+		if err != nil || parsed != test.amount {
+			t.Errorf("%q: parsed as %d (%v)", s, parsed, err)
+		}
+	}
+
+	if _, err := d.ParseAmount("0.0000001", Amount); !errors.Is(err, ErrPrecision) {
+		t.Errorf("expected ErrPrecision, got %v", err)
+	}
+	if a, err := d.ParseAmount("0.000001", Amount); err != nil || a != 1 {
+		t.Errorf("expected 1, got %d (%v)", a, err)
+	}
+}
+
+func TestDenominationArithmetic(t *testing.T) {
+	d := sidechain
+	if _, err := d.Add(d.MaxSupply, 1); err != ErrMoneyRange {
+		t.Errorf("expected ErrMoneyRange, got %v", err)
+	}
+	// Amounts beyond the default max supply are valid on the sidechain.
+	a, err := d.Sum(MaxSats, MaxSats)
+	if err != nil || a != 2*MaxSats {
+		t.Errorf("expected %d, got %d (%v)", AmountType(2*MaxSats), a, err)
+	}
+
+	// With the whole int64 range valid, overflows are still detected.
+	wide := &Denomination{Decimals: 8, MaxSupply: 1<<63 - 1}
+	if _, err := wide.Add(wide.MaxSupply, 1); err != ErrOverflow {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+	if _, err := wide.Sub(-wide.MaxSupply, 2); err != ErrOverflow {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+	if _, err := wide.Mul(wide.MaxSupply, 2); err != ErrOverflow {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+}
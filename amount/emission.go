@@ -0,0 +1,153 @@
+package amount
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var (
+	// ErrInvalidSchedule indicates an emission schedule with a negative
+	// initial reward, no blocks per epoch or a decay ratio which isn't
+	// below one.
+	ErrInvalidSchedule = errors.New("invalid emission schedule")
+
+	// ErrEmissionExceedsMax indicates an emission schedule which would emit
+	// more than the max supply of its denomination.
+	ErrEmissionExceedsMax = errors.New("emission exceeds max supply")
+)
+
+// EmissionSchedule describes the reward of each block.  Blocks are grouped in
+// epochs of EpochBlocks blocks, starting at height 0, which all have the same
+// reward.  At the start of each epoch the reward is multiplied by
+// DecayNum/DecayDen and rounded down to whole sats, until it reaches zero.
+//
+// Schedules should be checked with Validate, since the reward methods panic
+// when EpochBlocks or DecayDen is zero.
+type EmissionSchedule struct {
+	// InitialReward is the reward of every block in the first epoch.
+	InitialReward AmountType
+
+	// EpochBlocks is the number of blocks in an epoch.
+	EpochBlocks uint64
+
+	// DecayNum and DecayDen are the ratio by which the reward decays every
+	// epoch.  A ratio of 1/2 halves the reward.
+	DecayNum uint64
+	DecayDen uint64
+
+	// Denomination gives the max supply the schedule may emit.  Nil means
+	// DefaultDenomination.
+	Denomination *Denomination
+}
+
+// NewHalvingSchedule returns a schedule which halves the reward every
+// interval blocks, like Bitcoin.
+func NewHalvingSchedule(initialReward AmountType, interval uint64) *EmissionSchedule {
+	return NewDecaySchedule(initialReward, interval, 1, 2)
+}
+
+// NewDecaySchedule returns a schedule which multiplies the reward by num/den
+// every epochBlocks blocks.
+func NewDecaySchedule(initialReward AmountType, epochBlocks, num, den uint64) *EmissionSchedule {
+	return &EmissionSchedule{
+		InitialReward: initialReward,
+		EpochBlocks:   epochBlocks,
+		DecayNum:      num,
+		DecayDen:      den,
+	}
+}
+
+func (s *EmissionSchedule) denomination() *Denomination {
+	if s.Denomination != nil {
+		return s.Denomination
+	}
+	return DefaultDenomination
+}
+
+// Validate checks the parameters of the schedule, and that the total it will
+// ever emit doesn't exceed the max supply.
+func (s *EmissionSchedule) Validate() error {
+	_, err := s.TotalSupply()
+	return err
+}
+
+// checkParams returns ErrInvalidSchedule when the parameters are invalid.
+func (s *EmissionSchedule) checkParams() error {
+	if s.InitialReward < 0 || s.EpochBlocks == 0 || s.DecayDen == 0 || s.DecayNum >= s.DecayDen {
+		return ErrInvalidSchedule
+	}
+	return nil
+}
+
+// decay returns the reward of the epoch following one with the given reward.
+func (s *EmissionSchedule) decay(reward AmountType) AmountType {
+	// The product can't overflow a uint64 divided by DecayDen since
+	// DecayNum < DecayDen.
+	hi, lo := bits.Mul64(uint64(reward), s.DecayNum)
+	q, _ := bits.Div64(hi, lo, s.DecayDen)
+	return AmountType(q)
+}
+
+// Epoch returns the epoch of a block height.
+func (s *EmissionSchedule) Epoch(height uint64) uint64 {
+	return height / s.EpochBlocks
+}
+
+// RewardAtEpoch returns the reward of every block of an epoch.
+func (s *EmissionSchedule) RewardAtEpoch(epoch uint64) AmountType {
+	reward := s.InitialReward
+	for e := uint64(0); e < epoch && reward > 0; e++ {
+		reward = s.decay(reward)
+	}
+	return reward
+}
+
+// RewardAtHeight returns the reward of the block at a height.
+func (s *EmissionSchedule) RewardAtHeight(height uint64) AmountType {
+	return s.RewardAtEpoch(s.Epoch(height))
+}
+
+// SupplyAtHeight returns the total emitted by the blocks below a height, which
+// is the supply once the block at height - 1 is accepted.
+// ErrEmissionExceedsMax is returned if it exceeds the max supply.
+func (s *EmissionSchedule) SupplyAtHeight(height uint64) (AmountType, error) {
+	return s.supply(height, true)
+}
+
+// TotalSupply returns the total the schedule will ever emit.
+// ErrEmissionExceedsMax is returned if it exceeds the max supply.
+func (s *EmissionSchedule) TotalSupply() (AmountType, error) {
+	return s.supply(0, false)
+}
+
+// supply sums the rewards of the blocks below height, or of every block when
+// bounded is false.
+func (s *EmissionSchedule) supply(height uint64, bounded bool) (AmountType, error) {
+	if err := s.checkParams(); err != nil {
+		return 0, err
+	}
+	d := s.denomination()
+	var total AmountType
+	reward := s.InitialReward
+	for start := uint64(0); reward > 0 && (!bounded || start < height); start += s.EpochBlocks {
+		blocks := s.EpochBlocks
+		if bounded && height-start < blocks {
+			blocks = height - start
+		}
+		if blocks > uint64(d.MaxSupply) {
+			return 0, ErrEmissionExceedsMax
+		}
+		emitted, err := d.Mul(reward, int64(blocks))
+		if err == nil {
+			total, err = d.Add(total, emitted)
+		}
+		if err != nil {
+			return 0, ErrEmissionExceedsMax
+		}
+		if start > ^uint64(0)-s.EpochBlocks {
+			break
+		}
+		reward = s.decay(reward)
+	}
+	return total, nil
+}
@@ -0,0 +1,152 @@
+package amount
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHalvingSchedule(t *testing.T) {
+	// Bitcoin's schedule emits slightly less than 21 million coins, since
+	// rewards are rounded down to whole sats.
+	s := NewHalvingSchedule(50*SatsPerUnit, 210000)
+	if err := s.Validate(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	total, err := s.TotalSupply()
+	if err != nil || total != 2099999997690000 {
+		t.Errorf("expected total supply 2099999997690000, got %d (%v)", total, err)
+	}
+
+	rewards := []struct {
+		height uint64
+		reward AmountType
+	}{
+		{0, 50e8},
+		{209999, 50e8},
+		{210000, 25e8},
+		{420000, 1250000000},
+		{840000, 312500000},
+		{6929999, 1},
+		{6930000, 0},
+		{math.MaxUint64, 0},
+	}
+	for _, test := range rewards {
+		if r := s.RewardAtHeight(test.height); r != test.reward {
+			t.Errorf("height %d: expected reward %d, got %d", test.height, test.reward, r)
+		}
+	}
+
+	supplies := []struct {
+		height uint64
+		supply AmountType
+	}{
+		{0, 0},
+		{1, 50e8},
+		{210000, 210000 * 50e8},
+		{210001, 210000*50e8 + 25e8},
+		{math.MaxUint64, 2099999997690000},
+	}
+	for _, test := range supplies {
+		if supply, err := s.SupplyAtHeight(test.height); err != nil || supply != test.supply {
+			t.Errorf("height %d: expected supply %d, got %d (%v)", test.height, test.supply, supply, err)
+		}
+	}
+}
+
+func TestDecaySchedule(t *testing.T) {
+	s := NewDecaySchedule(1000, 10, 9, 10)
+	if err := s.Validate(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := []AmountType{1000, 900, 810, 729, 656, 590}
+	for epoch, reward := range expected {
+		if r := s.RewardAtEpoch(uint64(epoch)); r != reward {
+			t.Errorf("epoch %d: expected reward %d, got %d", epoch, reward, r)
+		}
+		if r := s.RewardAtHeight(uint64(epoch*10 + 9)); r != reward {
+			t.Errorf("epoch %d: expected reward %d at its last block, got %d", epoch, reward, r)
+		}
+	}
+
+	// The total is the sum of every epoch until the reward reaches zero.
+	var sum AmountType
+	for e := uint64(0); s.RewardAtEpoch(e) > 0; e++ {
+		sum += s.RewardAtEpoch(e) * 10
+	}
+	total, err := s.TotalSupply()
+	if err != nil || total != sum {
+		t.Errorf("expected total supply %d, got %d (%v)", sum, total, err)
+	}
+	supply, err := s.SupplyAtHeight(25)
+	if err != nil || supply != 10000+9000+5*810 {
+		t.Errorf("expected supply %d, got %d (%v)", 10000+9000+5*810, supply, err)
+	}
+}
+
+func TestEmissionScheduleValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		s    *EmissionSchedule
+		err  error
+	}{
+		{
+			name: "no blocks per epoch",
+			s:    NewHalvingSchedule(50e8, 0),
+			err:  ErrInvalidSchedule,
+		},
+		{
+			name: "no decay",
+			s:    NewDecaySchedule(50e8, 210000, 1, 1),
+			err:  ErrInvalidSchedule,
+		},
+		{
+			name: "zero denominator",
+			s:    NewDecaySchedule(50e8, 210000, 0, 0),
+			err:  ErrInvalidSchedule,
+		},
+		{
+			name: "negative reward",
+			s:    NewHalvingSchedule(-1, 210000),
+			err:  ErrInvalidSchedule,
+		},
+		{
+			name: "exceeds max supply",
+			s:    NewHalvingSchedule(100e8, 210000),
+			err:  ErrEmissionExceedsMax,
+		},
+		{
+			name: "reward exceeds max supply",
+			s:    NewHalvingSchedule(MaxSats+1, 1),
+			err:  ErrEmissionExceedsMax,
+		},
+		{
+			name: "epoch exceeds max supply",
+			s:    NewHalvingSchedule(1, math.MaxUint64),
+			err:  ErrEmissionExceedsMax,
+		},
+		{
+			name: "no reward",
+			s:    NewHalvingSchedule(0, 210000),
+			err:  nil,
+		},
+		{
+			name: "other denomination",
+			s: &EmissionSchedule{
+				InitialReward: 100e8,
+				EpochBlocks:   210000,
+				DecayNum:      1,
+				DecayDen:      2,
+				Denomination:  &Denomination{Decimals: 8, MaxSupply: 42e6 * SatsPerUnit},
+			},
+			err: nil,
+		},
+	}
+
+	for _, test := range tests {
+		if err := test.s.Validate(); err != test.err {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package amount
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrInvalidJSON indicates a JSON value which is neither a string nor an
+// integer.
+var ErrInvalidJSON = errors.New("amount must be a JSON string or integer")
+
+// MarshalText encodes the amount as its exact decimal value in the base unit,
+// without trailing zeros.
+func (a AmountType) MarshalText() ([]byte, error) {
+	return []byte(a.FormatWith(FormatOptions{Unit: Amount, TrimZeros: true})), nil
+}
+
+// UnmarshalText decodes an amount encoded by MarshalText.
+func (a *AmountType) UnmarshalText(text []byte) error {
+	v, err := ParseAmount(string(text), Amount)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// MarshalJSON encodes the amount as a JSON string with its exact decimal value
+// in the base unit, such as "1.5".  Use SatsJSON to encode an integer number
+// of sats instead.
+func (a AmountType) MarshalJSON() ([]byte, error) {
+	text, _ := a.MarshalText()
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes an amount from a JSON string with its decimal value in
+// the base unit, or from a JSON number with an integer number of sats.  Like
+// the standard library, null leaves the amount unchanged.  The range of the
+// amount is not checked, so callers should Validate it.
+func (a *AmountType) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return a.UnmarshalText([]byte(s))
+	}
+
+	v, err := ParseAmount(string(data), AmountSats)
+	if err != nil {
+		var perr *ParseError
+		if errors.As(err, &perr) && perr.Err == ErrSyntax {
+			return ErrInvalidJSON
+		}
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// SatsJSON is an amount encoded to JSON as an integer number of sats, such as
+// 150000000, rather than as a decimal string.  It decodes both formats like
+// AmountType.
+type SatsJSON AmountType
+
+// MarshalJSON encodes the amount as a JSON number of sats.
+func (a SatsJSON) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(a), 10)), nil
+}
+
+// UnmarshalJSON decodes an amount like AmountType.UnmarshalJSON.
+func (a *SatsJSON) UnmarshalJSON(data []byte) error {
+	return (*AmountType)(a).UnmarshalJSON(data)
+}
+
+// Value implements the driver.Valuer interface, storing the amount as an
+// integer number of sats.
+func (a AmountType) Value() (driver.Value, error) {
+	return int64(a), nil
+}
+
+// Scan implements the sql.Scanner interface.  It accepts integers, and
+// strings or bytes with an integer number of sats such as the text of a
+// NUMERIC column.  Floating point values are rejected since they may have
+// lost precision.  NULL is rejected too, since an AmountType can't represent
+// it; scan nullable columns into a *AmountType, which database/sql sets to nil
+// for NULL.
+func (a *AmountType) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case int64:
+		*a = AmountType(v)
+		return nil
+	case []byte:
+		return a.scanString(string(v))
+	case string:
+		return a.scanString(v)
+	default:
+		return fmt.Errorf("amount: cannot scan %T into AmountType", src)
+	}
+}
+
+func (a *AmountType) scanString(s string) error {
+	v, err := ParseAmount(s, AmountSats)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
@@ -0,0 +1,185 @@
+package amount
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+var encodingAmounts = []AmountType{
+	0, 1, -1, 29e6, 150000000, MaxSats, -MaxSats, MaxSats + 1, math.MaxInt64, math.MinInt64,
+}
+
+func TestAmountText(t *testing.T) {
+	tests := []struct {
+		amount AmountType
+		text   string
+	}{
+		{0, "0"},
+		{1, "0.00000001"},
+		{-150000000, "-1.5"},
+		{MaxSats, "21000000"},
+		{math.MaxInt64, "92233720368.54775807"},
+	}
+
+	for _, test := range tests {
+		text, err := test.amount.MarshalText()
+		if err != nil || string(text) != test.text {
+			t.Errorf("%d: expected %q, got %q (%v)", test.amount, test.text, text, err)
+		}
+	}
+
+	for _, a := range encodingAmounts {
+		text, _ := a.MarshalText()
+		var decoded AmountType
+		if err := decoded.UnmarshalText(text); err != nil || decoded != a {
+			t.Errorf("%d: decoded %q as %d (%v)", a, text, decoded, err)
+		}
+	}
+
+	var a AmountType
+	if err := a.UnmarshalText([]byte("0.000000001")); !errors.Is(err, ErrPrecision) {
+		t.Errorf("expected ErrPrecision, got %v", err)
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	type payment struct {
+		Amount AmountType  `json:"amount"`
+		Fee    *AmountType `json:"fee,omitempty"`
+	}
+	type satsPayment struct {
+		Amount SatsJSON  `json:"amount"`
+		Fee    *SatsJSON `json:"fee,omitempty"`
+	}
+
+	tests := []struct {
+		value interface{}
+		json  string
+	}{
+		{payment{Amount: MaxSats}, `{"amount":"21000000"}`},
+		{payment{Amount: -1}, `{"amount":"-0.00000001"}`},
+		{satsPayment{Amount: MaxSats}, `{"amount":2100000000000000}`},
+		{satsPayment{Amount: math.MinInt64}, `{"amount":-9223372036854775808}`},
+	}
+
+	for _, test := range tests {
+		data, err := json.Marshal(test.value)
+		if err != nil || string(data) != test.json {
+			t.Errorf("%+v: expected %s, got %s (%v)", test.value, test.json, data, err)
+		}
+	}
+
+	for _, a := range encodingAmounts {
+		fee := a
+		data, err := json.Marshal(payment{Amount: a, Fee: &fee})
+		if err != nil {
+			t.Fatalf("%d: unexpected error %v", a, err)
+		}
+		var decoded payment
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Errorf("%d: unexpected error decoding %s: %v", a, data, err)
+			continue
+		}
+		if decoded.Amount != a || decoded.Fee == nil || *decoded.Fee != a {
+			t.Errorf("%d: decoded %s as %+v", a, data, decoded)
+		}
+
+		// Both formats decode into either type.
+		var decodedSats satsPayment
+		if err := json.Unmarshal(data, &decodedSats); err != nil {
+			t.Errorf("%d: unexpected error decoding %s: %v", a, data, err)
+			continue
+		}
+		if AmountType(decodedSats.Amount) != a {
+			t.Errorf("%d: decoded %s as %+v", a, data, decodedSats)
+		}
+
+		satsFee := SatsJSON(a)
+		data, err = json.Marshal(satsPayment{Amount: SatsJSON(a), Fee: &satsFee})
+		if err != nil {
+			t.Fatalf("%d: unexpected error %v", a, err)
+		}
+		decoded = payment{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Errorf("%d: unexpected error decoding %s: %v", a, data, err)
+			continue
+		}
+		if decoded.Amount != a || decoded.Fee == nil || *decoded.Fee != a {
+			t.Errorf("%d: decoded %s as %+v", a, data, decoded)
+		}
+	}
+}
+
+func TestAmountUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected AmountType
+		err      error
+	}{
+		{json: `"1.5"`, expected: 150000000},
+		{json: ` 150000000 `, expected: 150000000},
+		{json: `null`, expected: 7},
+		{json: `1.5`, err: ErrPrecision},
+		{json: `"1.000000001"`, err: ErrPrecision},
+		{json: `9223372036854775808`, err: ErrRange},
+		{json: `"1,000"`, err: ErrSyntax},
+		{json: `1e3`, err: ErrInvalidJSON},
+		{json: `true`, err: ErrInvalidJSON},
+	}
+
+	for _, test := range tests {
+		a := AmountType(7)
+		err := a.UnmarshalJSON([]byte(test.json))
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%s: expected error %v, got %v", test.json, test.err, err)
+			}
+			continue
+		}
+		if err != nil || a != test.expected {
+			t.Errorf("%s: expected %d, got %d (%v)", test.json, test.expected, a, err)
+		}
+	}
+}
+
+func TestAmountSQL(t *testing.T) {
+	for _, a := range encodingAmounts {
+		v, err := a.Value()
+		if err != nil {
+			t.Fatalf("%d: unexpected error %v", a, err)
+		}
+		var scanned AmountType
+		if err := scanned.Scan(v); err != nil || scanned != a {
+			t.Errorf("%d: scanned %v as %d (%v)", a, v, scanned, err)
+		}
+	}
+
+	tests := []struct {
+		src      interface{}
+		expected AmountType
+		ok       bool
+	}{
+		{src: int64(42), expected: 42, ok: true},
+		{src: []byte("2100000000000000"), expected: MaxSats, ok: true},
+		{src: "-9223372036854775808", expected: math.MinInt64, ok: true},
+		{src: "150000000.000", expected: 150000000, ok: true},
+		{src: "1.5", ok: false},
+		{src: "9223372036854775808", ok: false},
+		{src: float64(1), ok: false},
+		{src: nil, ok: false},
+		{src: true, ok: false},
+	}
+
+	for _, test := range tests {
+		var a AmountType
+		err := a.Scan(test.src)
+		if test.ok && (err != nil || a != test.expected) {
+			t.Errorf("%v: expected %d, got %d (%v)", test.src, test.expected, a, err)
+		}
+		if !test.ok && err == nil {
+			t.Errorf("%v: expected error, got %d", test.src, a)
+		}
+	}
+}
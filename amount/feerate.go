@@ -0,0 +1,89 @@
+package amount
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+)
+
+// BytesPerKilobyte is the size a FeeRate is expressed for.
+const BytesPerKilobyte = 1000
+
+var (
+	// ErrNegativeFeeRate indicates a fee rate below zero.
+	ErrNegativeFeeRate = errors.New("negative fee rate")
+
+	// ErrInvalidSize indicates a size below zero, or a size of zero when
+	// computing a fee rate.
+	ErrInvalidSize = errors.New("invalid size")
+)
+
+// FeeRate is a fee rate in sats per kilobyte (1000 bytes).
+type FeeRate int64
+
+// NewFeeRatePerKB returns the fee rate of the given sats per kilobyte.
+func NewFeeRatePerKB(sats AmountType) (FeeRate, error) {
+	if sats < 0 {
+		return 0, ErrNegativeFeeRate
+	}
+	return FeeRate(sats), nil
+}
+
+// NewFeeRatePerByte returns the fee rate of the given sats per byte.
+func NewFeeRatePerByte(sats AmountType) (FeeRate, error) {
+	if sats < 0 {
+		return 0, ErrNegativeFeeRate
+	}
+	r := int64(sats) * BytesPerKilobyte
+	if r/BytesPerKilobyte != int64(sats) {
+		return 0, ErrOverflow
+	}
+	return FeeRate(r), nil
+}
+
+// NewFeeRateFromFee returns the fee rate of paying fee for size bytes,
+// rounded down to whole sats per kilobyte.  ErrOverflow is returned when the
+// rate doesn't fit in a FeeRate.
+func NewFeeRateFromFee(fee AmountType, size int64) (FeeRate, error) {
+	switch {
+	case fee < 0:
+		return 0, ErrNegativeFeeRate
+	case size <= 0:
+		return 0, ErrInvalidSize
+	}
+	r := new(big.Int).Mul(big.NewInt(int64(fee)), big.NewInt(BytesPerKilobyte))
+	r.Quo(r, big.NewInt(size))
+	if !r.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return FeeRate(r.Int64()), nil
+}
+
+// PerKB returns the fee rate in sats per kilobyte.
+func (r FeeRate) PerKB() AmountType {
+	return AmountType(r)
+}
+
+// Fee returns the fee for size bytes at this fee rate, rounded up to the next
+// whole sat so the fee paid never falls below the rate.  The fee must be valid
+// money.
+func (r FeeRate) Fee(size int64) (AmountType, error) {
+	switch {
+	case r < 0:
+		return 0, ErrNegativeFeeRate
+	case size < 0:
+		return 0, ErrInvalidSize
+	}
+	// ceil(r * size / 1000) = (r * size + 999) / 1000
+	fee := new(big.Int).Mul(big.NewInt(int64(r)), big.NewInt(size))
+	fee.Add(fee, big.NewInt(BytesPerKilobyte-1))
+	fee.Quo(fee, big.NewInt(BytesPerKilobyte))
+	if !fee.IsInt64() {
+		return 0, ErrMoneyRange
+	}
+	return DefaultDenomination.checked(AmountType(fee.Int64()))
+}
+
+func (r FeeRate) String() string {
+	return strconv.FormatInt(int64(r), 10) + " sat/kB"
+}
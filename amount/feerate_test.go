@@ -0,0 +1,75 @@
+package amount
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFeeRateFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     FeeRate
+		size     int64
+		expected AmountType
+		err      error
+	}{
+		{name: "exact", rate: 1000, size: 250, expected: 250},
+		{name: "rounded up", rate: 1001, size: 250, expected: 251},
+		{name: "below one sat", rate: 1, size: 1, expected: 1},
+		{name: "zero size", rate: 1000, size: 0, expected: 0},
+		{name: "zero rate", rate: 0, size: 250, expected: 0},
+		{name: "negative rate", rate: -1, size: 250, err: ErrNegativeFeeRate},
+		{name: "negative size", rate: 1000, size: -1, err: ErrInvalidSize},
+		{name: "beyond max", rate: math.MaxInt64, size: math.MaxInt64, err: ErrMoneyRange},
+	}
+
+	for _, test := range tests {
+		fee, err := test.rate.Fee(test.size)
+		if err != test.err {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+			continue
+		}
+		if fee != test.expected {
+			t.Errorf("%v: expected fee %d, got %d", test.name, test.expected, fee)
+		}
+	}
+}
+
+func TestNewFeeRate(t *testing.T) {
+	r, err := NewFeeRatePerByte(5)
+	if err != nil || r != 5000 {
+		t.Errorf("expected 5000 sat/kB, got %v (%v)", r, err)
+	}
+	if r.String() != "5000 sat/kB" {
+		t.Errorf("unexpected string %q", r.String())
+	}
+	if _, err := NewFeeRatePerByte(math.MaxInt64 / 100); err != ErrOverflow {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+	if _, err := NewFeeRatePerByte(-1); err != ErrNegativeFeeRate {
+		t.Errorf("expected ErrNegativeFeeRate, got %v", err)
+	}
+
+	r, err = NewFeeRatePerKB(1234)
+	if err != nil || r.PerKB() != 1234 {
+		t.Errorf("expected 1234 sat/kB, got %v (%v)", r, err)
+	}
+	if _, err := NewFeeRatePerKB(-1); err != ErrNegativeFeeRate {
+		t.Errorf("expected ErrNegativeFeeRate, got %v", err)
+	}
+
+	r, err = NewFeeRateFromFee(1000, 300)
+	if err != nil || r != 3333 {
+		t.Errorf("expected 3333 sat/kB, got %v (%v)", r, err)
+	}
+	if _, err := NewFeeRateFromFee(1000, 0); err != ErrInvalidSize {
+		t.Errorf("expected ErrInvalidSize, got %v", err)
+	}
+	if _, err := NewFeeRateFromFee(1<<62, 1); err != ErrOverflow {
+		t.Errorf("expected ErrOverflow, got %v", err)
+	}
+	r, err = NewFeeRateFromFee(1<<62, 1000)
+	if err != nil || r != 1<<62 {
+		t.Errorf("expected %d sat/kB, got %v (%v)", int64(1<<62), r, err)
+	}
+}
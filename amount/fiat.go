@@ -0,0 +1,261 @@
+package amount
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+var (
+	// ErrInvalidCurrency indicates a currency without a code or with an
+	// unsupported number of decimals.
+	ErrInvalidCurrency = errors.New("invalid currency")
+
+	// ErrInvalidRate indicates an exchange rate which isn't positive.
+	ErrInvalidRate = errors.New("exchange rate must be positive")
+
+	// ErrCurrencyMismatch indicates a fiat amount in a currency other than
+	// that of the exchange rate.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+
+	// ErrUnknownCurrency is returned by a RateSource without a rate for a
+	// currency.
+	ErrUnknownCurrency = errors.New("unknown currency")
+
+	// ErrFiatRange indicates a fiat amount which doesn't fit in an int64
+	// of minor units.
+	ErrFiatRange = errors.New("fiat amount out of range")
+)
+
+// Currency is a fiat currency, identified by an arbitrary code such as "USD".
+type Currency struct {
+	// Code identifies the currency.
+	Code string
+
+	// Decimals is the number of decimals of the currency, 2 for cents.
+	Decimals int
+}
+
+// Validate returns ErrInvalidCurrency when the code is empty or the number of
+// decimals is outside [0, MaxDecimals].
+func (c Currency) Validate() error {
+	if c.Code == "" || c.Decimals < 0 || c.Decimals > MaxDecimals {
+		return ErrInvalidCurrency
+	}
+	return nil
+}
+
+// FiatAmount is an amount of a fiat currency, counted in its minor unit.
+type FiatAmount struct {
+	Currency Currency
+	Minor    int64
+}
+
+// ParseFiat parses the exact decimal value of an amount in the major unit of
+// a currency, such as "12.34".  It fails like ParseAmount.
+func ParseFiat(c Currency, s string) (FiatAmount, error) {
+	if err := c.Validate(); err != nil {
+		return FiatAmount{}, err
+	}
+	minor, err := parseAmount(s, c.Decimals, ParseOptions{})
+	if err != nil {
+		return FiatAmount{}, &ParseError{Input: s, Err: err}
+	}
+	return FiatAmount{Currency: c, Minor: int64(minor)}, nil
+}
+
+// String returns the exact decimal value of the amount followed by the code
+// of its currency, such as "12.34 USD".
+func (f FiatAmount) String() string {
+	intPart, fracPart := decimalParts(AmountType(f.Minor), f.Currency.Decimals)
+	var b strings.Builder
+	if f.Minor < 0 {
+		b.WriteByte('-')
+	}
+	b.WriteString(intPart)
+	if fracPart != "" {
+		b.WriteByte('.')
+		b.WriteString(fracPart)
+	}
+	b.WriteByte(' ')
+	b.WriteString(f.Currency.Code)
+	return b.String()
+}
+
+// RoundingMode selects how conversions round to whole minor units or sats.
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds to the nearest value, and ties to the even one.
+	RoundHalfEven RoundingMode = iota
+
+	// RoundDown rounds toward zero.
+	RoundDown
+
+	// RoundUp rounds away from zero.
+	RoundUp
+)
+
+// roundRat rounds a rational to an integer with the given mode.
+func roundRat(r *big.Rat, mode RoundingMode) *big.Int {
+	q, m := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() == 0 {
+		return q
+	}
+
+	away := false
+	switch mode {
+	case RoundUp:
+		away = true
+	case RoundHalfEven:
+		// Compare the remainder with half the denominator.
+		switch new(big.Int).Lsh(m.Abs(m), 1).Cmp(r.Denom()) {
+		case 1:
+			away = true
+		case 0:
+			away = q.Bit(0) == 1
+		}
+	}
+	if away {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+	return q
+}
+
+// ExchangeRate is the price of one base unit of a denomination in the major
+// unit of a currency.
+type ExchangeRate struct {
+	Currency Currency
+
+	// Rate is the price of one base unit.  It must be positive.
+	Rate *big.Rat
+
+	// Denomination is the denomination of the amounts converted.  Nil
+	// means DefaultDenomination.
+	Denomination *Denomination
+}
+
+// NewExchangeRate returns the exchange rate of a currency from the exact
+// decimal value of the price of one base unit, such as "1234.5678".  The rate
+// has the syntax of ParseAmount with any number of decimals, so fractions such
+// as "1/3" and exponents are rejected.
+func NewExchangeRate(c Currency, rate string) (*ExchangeRate, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	r, ok := parseDecimal(rate)
+	if !ok {
+		return nil, &ParseError{Input: rate, Err: ErrSyntax}
+	}
+	if r.Sign() <= 0 {
+		return nil, ErrInvalidRate
+	}
+	return &ExchangeRate{Currency: c, Rate: r}, nil
+}
+
+// parseDecimal parses an optionally signed decimal number made of digits with
+// an optional decimal point.
+func parseDecimal(s string) (*big.Rat, bool) {
+	digits := s
+	if digits != "" && (digits[0] == '+' || digits[0] == '-') {
+		digits = digits[1:]
+	}
+	intPart, fracPart := digits, ""
+	if i := strings.IndexByte(digits, '.'); i >= 0 {
+		intPart, fracPart = digits[:i], digits[i+1:]
+	}
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
+
+func (r *ExchangeRate) denomination() *Denomination {
+	if r.Denomination != nil {
+		return r.Denomination
+	}
+	return DefaultDenomination
+}
+
+// minorPerSat returns the value of one sat in minor units of the currency.
+func (r *ExchangeRate) minorPerSat() (*big.Rat, error) {
+	if err := r.Currency.Validate(); err != nil {
+		return nil, err
+	}
+	if r.Rate == nil || r.Rate.Sign() <= 0 {
+		return nil, ErrInvalidRate
+	}
+	d := r.denomination()
+	scale := new(big.Rat).SetFrac(pow10(int64(r.Currency.Decimals)), pow10(int64(d.Decimals)))
+	return scale.Mul(scale, r.Rate), nil
+}
+
+// ToFiat converts an amount to the currency, rounded to whole minor units with
+// the given mode.
+func (r *ExchangeRate) ToFiat(a AmountType, mode RoundingMode) (FiatAmount, error) {
+	perSat, err := r.minorPerSat()
+	if err != nil {
+		return FiatAmount{}, err
+	}
+	minor := roundRat(perSat.Mul(perSat, new(big.Rat).SetInt64(int64(a))), mode)
+	if !minor.IsInt64() {
+		return FiatAmount{}, ErrFiatRange
+	}
+	return FiatAmount{Currency: r.Currency, Minor: minor.Int64()}, nil
+}
+
+// FromFiat converts a fiat amount to an amount, rounded to whole sats with the
+// given mode.  The result must be valid money for the denomination.
+func (r *ExchangeRate) FromFiat(f FiatAmount, mode RoundingMode) (AmountType, error) {
+	if f.Currency != r.Currency {
+		return 0, ErrCurrencyMismatch
+	}
+	perSat, err := r.minorPerSat()
+	if err != nil {
+		return 0, err
+	}
+	sats := roundRat(new(big.Rat).Quo(new(big.Rat).SetInt64(f.Minor), perSat), mode)
+	if !sats.IsInt64() {
+		return 0, ErrMoneyRange
+	}
+	return r.denomination().checked(AmountType(sats.Int64()))
+}
+
+// RateSource provides exchange rates by currency code.  Implementations may
+// query a price feed; FixedRates serves a fixed table.
+type RateSource interface {
+	// Rate returns the exchange rate of a currency, or an error wrapping
+	// ErrUnknownCurrency when there is none.
+	Rate(code string) (*ExchangeRate, error)
+}
+
+// FixedRates is a RateSource serving a fixed table of rates by currency code.
+type FixedRates map[string]*ExchangeRate
+
+// Rate returns the exchange rate of a currency.
+func (t FixedRates) Rate(code string) (*ExchangeRate, error) {
+	r, ok := t[code]
+	if !ok {
+		return nil, ErrUnknownCurrency
+	}
+	return r, nil
+}
+
+// ToFiat converts an amount to a currency using the rate given by a source.
+func ToFiat(src RateSource, a AmountType, code string, mode RoundingMode) (FiatAmount, error) {
+	r, err := src.Rate(code)
+	if err != nil {
+		return FiatAmount{}, err
+	}
+	return r.ToFiat(a, mode)
+}
+
+// FromFiat converts a fiat amount to an amount using the rate of its currency
+// given by a source.
+func FromFiat(src RateSource, f FiatAmount, mode RoundingMode) (AmountType, error) {
+	r, err := src.Rate(f.Currency.Code)
+	if err != nil {
+		return 0, err
+	}
+	return r.FromFiat(f, mode)
+}
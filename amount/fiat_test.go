@@ -0,0 +1,179 @@
+package amount
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+var (
+	usd = Currency{Code: "USD", Decimals: 2}
+	jpy = Currency{Code: "JPY", Decimals: 0}
+	bhd = Currency{Code: "BHD", Decimals: 3}
+)
+
+func TestRoundRat(t *testing.T) {
+	tests := []struct {
+		rat      string
+		halfEven int64
+		down     int64
+		up       int64
+	}{
+		{"2", 2, 2, 2},
+		{"2.4", 2, 2, 3},
+		{"2.5", 2, 2, 3},
+		{"2.6", 3, 2, 3},
+		{"3.5", 4, 3, 4},
+		{"-2.5", -2, -2, -3},
+		{"-3.5", -4, -3, -4},
+		{"-0.4", 0, 0, -1},
+		{"-2.6", -3, -2, -3},
+	}
+
+	for _, test := range tests {
+		r, _ := new(big.Rat).SetString(test.rat)
+		modes := []struct {
+			mode     RoundingMode
+			expected int64
+		}{
+			{RoundHalfEven, test.halfEven},
+			{RoundDown, test.down},
+			{RoundUp, test.up},
+		}
+		for _, m := range modes {
+			if v := roundRat(r, m.mode).Int64(); v != m.expected {
+				t.Errorf("%v in mode %d: expected %d, got %d", test.rat, m.mode, m.expected, v)
+			}
+		}
+	}
+}
+
+func TestExchangeRate(t *testing.T) {
+	rate, err := NewExchangeRate(usd, "12.345")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	tests := []struct {
+		amount AmountType
+		mode   RoundingMode
+		fiat   string
+	}{
+		{1e8, RoundHalfEven, "12.34 USD"},
+		{1e8, RoundDown, "12.34 USD"},
+		{1e8, RoundUp, "12.35 USD"},
+		{3e8, RoundHalfEven, "37.04 USD"},
+		{-1e8, RoundUp, "-12.35 USD"},
+		{1, RoundUp, "0.01 USD"},
+		{1, RoundHalfEven, "0.00 USD"},
+		{MaxSats, RoundHalfEven, "259245000.00 USD"},
+	}
+
+	for _, test := range tests {
+		f, err := rate.ToFiat(test.amount, test.mode)
+		if err != nil || f.String() != test.fiat {
+			t.Errorf("%d in mode %d: expected %v, got %v (%v)", test.amount, test.mode, test.fiat, f, err)
+		}
+	}
+
+	if _, err := ParseFiat(usd, "12.345"); !errors.Is(err, ErrPrecision) {
+		t.Errorf("expected ErrPrecision, got %v", err)
+	}
+	f, err := ParseFiat(usd, "24.69")
+	if err != nil || f.Minor != 2469 {
+		t.Fatalf("expected 2469 cents, got %v (%v)", f, err)
+	}
+	if a, err := rate.FromFiat(f, RoundHalfEven); err != nil || a != 2e8 {
+		t.Errorf("expected 2e8 sats, got %d (%v)", a, err)
+	}
+
+	// 0.01 USD is 81004.4552 sats.
+	cent := FiatAmount{Currency: usd, Minor: 1}
+	for mode, expected := range map[RoundingMode]AmountType{RoundHalfEven: 81004, RoundDown: 81004, RoundUp: 81005} {
+		if a, err := rate.FromFiat(cent, mode); err != nil || a != expected {
+			t.Errorf("mode %d: expected %d sats, got %d (%v)", mode, expected, a, err)
+		}
+	}
+}
+
+func TestExchangeRateCurrencies(t *testing.T) {
+	yen, err := NewExchangeRate(jpy, "1500.5")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if f, err := yen.ToFiat(1e8, RoundHalfEven); err != nil || f.String() != "1500 JPY" {
+		t.Errorf("expected 1500 JPY, got %v (%v)", f, err)
+	}
+	if f, err := yen.ToFiat(3e8, RoundHalfEven); err != nil || f.String() != "4502 JPY" {
+		t.Errorf("expected 4502 JPY, got %v (%v)", f, err)
+	}
+
+	dinar, err := NewExchangeRate(bhd, "0.001")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if f, err := dinar.ToFiat(1e8, RoundDown); err != nil || f.String() != "0.001 BHD" {
+		t.Errorf("expected 0.001 BHD, got %v (%v)", f, err)
+	}
+
+	// Rates can be given for other denominations.
+	side := &ExchangeRate{Currency: usd, Rate: big.NewRat(2, 1), Denomination: sidechain}
+	if f, err := side.ToFiat(1500000, RoundHalfEven); err != nil || f.String() != "3.00 USD" {
+		t.Errorf("expected 3.00 USD, got %v (%v)", f, err)
+	}
+}
+
+func TestExchangeRateErrors(t *testing.T) {
+	if _, err := NewExchangeRate(Currency{Decimals: 2}, "1"); err != ErrInvalidCurrency {
+		t.Errorf("expected ErrInvalidCurrency, got %v", err)
+	}
+	if _, err := NewExchangeRate(usd, "0"); err != ErrInvalidRate {
+		t.Errorf("expected ErrInvalidRate, got %v", err)
+	}
+	if _, err := NewExchangeRate(usd, "-1"); err != ErrInvalidRate {
+		t.Errorf("expected ErrInvalidRate, got %v", err)
+	}
+	for _, s := range []string{"abc", "", ".", "1/3", "1e3", "1.5E-2", "0x10", "1,000", " 1"} {
+		if _, err := NewExchangeRate(usd, s); !errors.Is(err, ErrSyntax) {
+			t.Errorf("%q: expected ErrSyntax, got %v", s, err)
+		}
+	}
+
+	rate, _ := NewExchangeRate(usd, "1000000000000")
+	if _, err := rate.ToFiat(math.MaxInt64, RoundDown); err != ErrFiatRange {
+		t.Errorf("expected ErrFiatRange, got %v", err)
+	}
+	if _, err := rate.FromFiat(FiatAmount{Currency: jpy, Minor: 1}, RoundDown); err != ErrCurrencyMismatch {
+		t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+	}
+
+	cheap, _ := NewExchangeRate(usd, "0.00000001")
+	if _, err := cheap.FromFiat(FiatAmount{Currency: usd, Minor: math.MaxInt64}, RoundDown); err != ErrMoneyRange {
+		t.Errorf("expected ErrMoneyRange, got %v", err)
+	}
+	if _, err := cheap.FromFiat(FiatAmount{Currency: usd, Minor: 1e8}, RoundDown); err != ErrMoneyRange {
+		t.Errorf("expected ErrMoneyRange, got %v", err)
+	}
+}
+
+func TestRateSource(t *testing.T) {
+	rate, _ := NewExchangeRate(usd, "10")
+	var src RateSource = FixedRates{"USD": rate}
+
+	f, err := ToFiat(src, 5e7, "USD", RoundHalfEven)
+	if err != nil || f.String() != "5.00 USD" {
+		t.Errorf("expected 5.00 USD, got %v (%v)", f, err)
+	}
+	a, err := FromFiat(src, f, RoundHalfEven)
+	if err != nil || a != 5e7 {
+		t.Errorf("expected 5e7 sats, got %d (%v)", a, err)
+	}
+
+	if _, err := ToFiat(src, 1, "EUR", RoundHalfEven); err != ErrUnknownCurrency {
+		t.Errorf("expected ErrUnknownCurrency, got %v", err)
+	}
+	if _, err := FromFiat(src, FiatAmount{Currency: jpy}, RoundHalfEven); err != ErrUnknownCurrency {
+		t.Errorf("expected ErrUnknownCurrency, got %v", err)
+	}
+}
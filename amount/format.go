@@ -0,0 +1,259 @@
+package amount
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidLocale indicates a locale without a decimal separator, without a
+// group separator when grouping, or using the same separator for both.
+var ErrInvalidLocale = errors.New("invalid locale")
+
+// UnitNames are the names used to display the units of a coin, which may
+// differ between networks.
+type UnitNames struct {
+	// Ticker is the name of the base unit, such as "POLIS".  The names of
+	// the other units prefix it with an SI prefix.
+	Ticker string
+
+	// Sats is the name of the smallest unit.
+	Sats string
+}
+
+// DefaultUnitNames are the unit names used by AmountUnit.String.
+var DefaultUnitNames = &UnitNames{
+	Ticker: "POLIS",
+	Sats:   "Sats",
+}
+
+// Name returns the name of a unit, such as "MPOLIS" or "μPOLIS".  Units
+// without a name are shown as a power of ten of the base unit, such as
+// "1e-1 POLIS".
+func (n *UnitNames) Name(u AmountUnit) string {
+	switch u {
+	case AmountMega:
+		return "M" + n.Ticker
+	case AmountKilo:
+		return "k" + n.Ticker
+	case Amount:
+		return n.Ticker
+	case AmountMilli:
+		return "m" + n.Ticker
+	case AmountMicro:
+		return "μ" + n.Ticker
+	case AmountSats:
+		return n.Sats
+	default:
+		return n.powerName(u)
+	}
+}
+
+// powerName names a unit as a power of ten of the base unit.
+func (n *UnitNames) powerName(u AmountUnit) string {
+	return "1e" + strconv.FormatInt(int64(u), 10) + " " + n.Ticker
+}
+
+// String returns the name of the unit using DefaultUnitNames.
+func (u AmountUnit) String() string {
+	return DefaultUnitNames.Name(u)
+}
+
+// Locale holds the separators used to format numbers.
+type Locale struct {
+	// Decimal separates the integer part from the decimals.
+	Decimal string
+
+	// Group separates groups of three digits in the integer part.
+	Group string
+}
+
+var (
+	// LocaleDefault formats numbers like 1,234.5.
+	LocaleDefault = Locale{Decimal: ".", Group: ","}
+
+	// LocaleEuropean formats numbers like 1.234,5.
+	LocaleEuropean = Locale{Decimal: ",", Group: "."}
+
+	// LocaleSwiss formats numbers like 1'234.5.
+	LocaleSwiss = Locale{Decimal: ".", Group: "'"}
+
+	// LocaleFrench formats numbers like 1 234,5, with a narrow no-break
+	// space.
+	LocaleFrench = Locale{Decimal: ",", Group: "\u202f"}
+)
+
+// FormatOptions control how amounts are formatted by FormatWith and parsed by
+// ParseAmountWith.
+type FormatOptions struct {
+	// Unit is the unit the amount is expressed in.
+	Unit AmountUnit
+
+	// Suffix appends a space and the name of the unit.
+	Suffix bool
+
+	// Names are the names of the units.  Nil means the names of the
+	// denomination.
+	Names *UnitNames
+
+	// TrimZeros removes trailing zeros from the decimals, and the decimal
+	// separator when no decimals remain.
+	TrimZeros bool
+
+	// Group separates groups of three digits in the integer part.
+	Group bool
+
+	// Locale holds the separators.  The zero value means LocaleDefault,
+	// while any other value is used as is.
+	Locale Locale
+}
+
+// locale returns the locale of the options, or ErrInvalidLocale when its
+// separators can't be told apart.
+func (o *FormatOptions) locale() (Locale, error) {
+	l := o.Locale
+	switch {
+	case l == Locale{}:
+		return LocaleDefault, nil
+	case l.Decimal == "" || l.Decimal == l.Group:
+		return Locale{}, ErrInvalidLocale
+	case o.Group && l.Group == "":
+		return Locale{}, ErrInvalidLocale
+	}
+	return l, nil
+}
+
+// FormatUnit formats the amount in the given unit followed by the name of the
+// unit, such as "1.5 POLIS".
+func (a AmountType) FormatUnit(u AmountUnit) string {
+	return DefaultDenomination.FormatUnit(a, u)
+}
+
+// FormatWith formats the amount exactly, with every decimal of the unit
+// unless trimmed.  An invalid locale is replaced by LocaleDefault; see
+// Denomination.FormatWith.
+func (a AmountType) FormatWith(opts FormatOptions) string {
+	return DefaultDenomination.FormatWith(a, opts)
+}
+
+// Format formats the exact value of the amount in the given unit, without
+// trailing zeros.
+func (d *Denomination) Format(a AmountType, u AmountUnit) string {
+	return d.FormatWith(a, FormatOptions{Unit: u, TrimZeros: true})
+}
+
+// FormatUnit formats the amount in the given unit followed by the name of the
+// unit.
+func (d *Denomination) FormatUnit(a AmountType, u AmountUnit) string {
+	return d.FormatWith(a, FormatOptions{Unit: u, Suffix: true, TrimZeros: true})
+}
+
+// FormatWith formats the amount exactly, with every decimal of the unit
+// unless trimmed.
+//
+// A locale which ParseAmountWith rejects with ErrInvalidLocale, such as one
+// using the same separator for decimals and groups, is replaced by
+// LocaleDefault so the amount is never formatted ambiguously.
+func (d *Denomination) FormatWith(a AmountType, opts FormatOptions) string {
+	intPart, fracPart := decimalParts(a, int(opts.Unit)+d.Decimals)
+	if opts.TrimZeros {
+		fracPart = strings.TrimRight(fracPart, "0")
+	}
+
+	l, err := opts.locale()
+	if err != nil {
+		l = LocaleDefault
+	}
+	var b strings.Builder
+	if a < 0 {
+		b.WriteByte('-')
+	}
+	for i := range intPart {
+		if opts.Group && i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteByte(intPart[i])
+	}
+	if fracPart != "" {
+		b.WriteString(l.Decimal)
+		b.WriteString(fracPart)
+	}
+	if opts.Suffix {
+		b.WriteByte(' ')
+		b.WriteString(d.unitName(opts.Names, opts.Unit))
+	}
+	return b.String()
+}
+
+// decimalParts returns the digits of the absolute value of a divided by
+// 10^decimals, split at the decimal point.
+func decimalParts(a AmountType, decimals int) (string, string) {
+	// The absolute value of math.MinInt64 only fits in a uint64.
+	abs := uint64(a)
+	if a < 0 {
+		abs = -abs
+	}
+	digits := strconv.FormatUint(abs, 10)
+	if decimals <= 0 {
+		if abs == 0 {
+			return digits, ""
+		}
+		return digits + strings.Repeat("0", -decimals), ""
+	}
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	return digits[:len(digits)-decimals], digits[len(digits)-decimals:]
+}
+
+// ParseAmountWith parses an amount formatted by FormatWith with the same
+// options.  Trailing zeros are optional, and so are group separators when
+// grouping is enabled.  The unit name is required when Suffix is set.
+// ErrInvalidLocale is returned when the separators of the locale can't be
+// told apart.
+func ParseAmountWith(s string, opts FormatOptions) (AmountType, error) {
+	return DefaultDenomination.ParseAmountWith(s, opts)
+}
+
+// ParseAmountWith parses an amount formatted by FormatWith with the same
+// options.
+func (d *Denomination) ParseAmountWith(s string, opts FormatOptions) (AmountType, error) {
+	a, err := d.parseAmountWith(s, opts)
+	if err != nil {
+		return 0, &ParseError{Input: s, Err: err}
+	}
+	return a, nil
+}
+
+func (d *Denomination) parseAmountWith(s string, opts FormatOptions) (AmountType, error) {
+	if opts.Suffix {
+		suffix := " " + d.unitName(opts.Names, opts.Unit)
+		if !strings.HasSuffix(s, suffix) {
+			return 0, ErrSyntax
+		}
+		s = s[:len(s)-len(suffix)]
+	}
+
+	// Translate the separators of the locale to those of ParseAmount.
+	l, err := opts.locale()
+	if err != nil {
+		return 0, err
+	}
+	var b strings.Builder
+	for len(s) > 0 {
+		switch {
+		case strings.HasPrefix(s, l.Decimal):
+			b.WriteByte('.')
+			s = s[len(l.Decimal):]
+		case opts.Group && strings.HasPrefix(s, l.Group):
+			b.WriteByte(',')
+			s = s[len(l.Group):]
+		case s[0] == '.' || s[0] == ',':
+			return 0, ErrSyntax
+		default:
+			b.WriteByte(s[0])
+			s = s[1:]
+		}
+	}
+	return parseAmount(b.String(), int(opts.Unit)+d.Decimals, ParseOptions{AllowThousands: opts.Group})
+}
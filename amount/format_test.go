@@ -0,0 +1,234 @@
+package amount
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAmountUnitString(t *testing.T) {
+	tests := []struct {
+		unit AmountUnit
+		s    string
+	}{
+		{AmountMega, "MPOLIS"},
+		{AmountKilo, "kPOLIS"},
+		{Amount, "POLIS"},
+		{AmountMilli, "mPOLIS"},
+		{AmountMicro, "μPOLIS"},
+		{AmountSats, "Sats"},
+		{AmountUnit(-1), "1e-1 POLIS"},
+	}
+
+	for _, test := range tests {
+		if s := test.unit.String(); s != test.s {
+			t.Errorf("%d: expected %q, got %q", test.unit, test.s, s)
+		}
+	}
+
+	names := &UnitNames{Ticker: "tPOLIS", Sats: "tSats"}
+	if s := names.Name(AmountKilo); s != "ktPOLIS" {
+		t.Errorf("expected ktPOLIS, got %q", s)
+	}
+	if s := names.Name(AmountSats); s != "tSats" {
+		t.Errorf("expected tSats, got %q", s)
+	}
+}
+
+func TestAmountFormatWith(t *testing.T) {
+	testnet := &UnitNames{Ticker: "tPOLIS", Sats: "tSats"}
+	tests := []struct {
+		name   string
+		amount AmountType
+		opts   FormatOptions
+		s      string
+	}{
+		{
+			name:   "all decimals",
+			amount: 150000000,
+			opts:   FormatOptions{Unit: Amount},
+			s:      "1.50000000",
+		},
+		{
+			name:   "trimmed",
+			amount: 150000000,
+			opts:   FormatOptions{Unit: Amount, TrimZeros: true},
+			s:      "1.5",
+		},
+		{
+			name:   "trimmed integer",
+			amount: 2e8,
+			opts:   FormatOptions{Unit: Amount, TrimZeros: true},
+			s:      "2",
+		},
+		{
+			name:   "one sat",
+			amount: 1,
+			opts:   FormatOptions{Unit: Amount},
+			s:      "0.00000001",
+		},
+		{
+			name:   "negative",
+			amount: -12345,
+			opts:   FormatOptions{Unit: AmountMilli, Suffix: true},
+			s:      "-0.12345 mPOLIS",
+		},
+		{
+			name:   "sats",
+			amount: 1234567,
+			opts:   FormatOptions{Unit: AmountSats, Group: true, Suffix: true},
+			s:      "1,234,567 Sats",
+		},
+		{
+			name:   "grouped",
+			amount: MaxSats,
+			opts:   FormatOptions{Unit: Amount, Group: true, TrimZeros: true},
+			s:      "21,000,000",
+		},
+		{
+			name:   "european",
+			amount: 123456789012,
+			opts:   FormatOptions{Unit: Amount, Group: true, Locale: LocaleEuropean},
+			s:      "1.234,56789012",
+		},
+		{
+			name:   "french",
+			amount: 123456789012,
+			opts:   FormatOptions{Unit: Amount, Group: true, TrimZeros: true, Locale: LocaleFrench},
+			s:      "1\u202f234,56789012",
+		},
+		{
+			name:   "decimal separator only",
+			amount: 150000000,
+			opts:   FormatOptions{Unit: Amount, TrimZeros: true, Locale: Locale{Decimal: ","}},
+			s:      "1,5",
+		},
+		{
+			name:   "network names",
+			amount: 5e7,
+			opts:   FormatOptions{Unit: Amount, Suffix: true, TrimZeros: true, Names: testnet},
+			s:      "0.5 tPOLIS",
+		},
+		{
+			name:   "max int64",
+			amount: math.MaxInt64,
+			opts:   FormatOptions{Unit: Amount, Group: true},
+			s:      "92,233,720,368.54775807",
+		},
+		{
+			name:   "min int64",
+			amount: math.MinInt64,
+			opts:   FormatOptions{Unit: AmountSats},
+			s:      "-9223372036854775808",
+		},
+	}
+
+	for _, test := range tests {
+		s := test.amount.FormatWith(test.opts)
+		if s != test.s {
+			t.Errorf("%v: expected %q, got %q", test.name, test.s, s)
+			continue
+		}
+
+		a, err := ParseAmountWith(s, test.opts)
+		if err != nil {
+			t.Errorf("%v: unexpected error parsing %q: %v", test.name, s, err)
+			continue
+		}
+		if a != test.amount {
+			t.Errorf("%v: parsed %q as %d, expected %d", test.name, s, a, test.amount)
+		}
+	}
+}
+
+func TestAmountFormatUnit(t *testing.T) {
+	if s := AmountType(123456789).FormatUnit(AmountMilli); s != "1234.56789 mPOLIS" {
+		t.Errorf("unexpected format %q", s)
+	}
+	if s := AmountType(0).FormatUnit(Amount); s != "0 POLIS" {
+		t.Errorf("unexpected format %q", s)
+	}
+}
+
+func TestParseAmountWithErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		opts FormatOptions
+		err  error
+	}{
+		{
+			name: "missing suffix",
+			s:    "1.5",
+			opts: FormatOptions{Unit: Amount, Suffix: true},
+			err:  ErrSyntax,
+		},
+		{
+			name: "wrong suffix",
+			s:    "1.5 mPOLIS",
+			opts: FormatOptions{Unit: Amount, Suffix: true},
+			err:  ErrSyntax,
+		},
+		{
+			name: "wrong decimal separator",
+			s:    "1.5",
+			opts: FormatOptions{Unit: Amount, Locale: LocaleEuropean},
+			err:  ErrSyntax,
+		},
+		{
+			name: "grouping not enabled",
+			s:    "1,000",
+			opts: FormatOptions{Unit: Amount},
+			err:  ErrSyntax,
+		},
+		{
+			name: "misplaced group",
+			s:    "10.00,5",
+			opts: FormatOptions{Unit: Amount, Group: true, Locale: LocaleEuropean},
+			err:  ErrSyntax,
+		},
+		{
+			name: "same separators",
+			s:    "1.5",
+			opts: FormatOptions{Unit: Amount, Locale: Locale{Decimal: ".", Group: "."}},
+			err:  ErrInvalidLocale,
+		},
+		{
+			name: "group separator only",
+			s:    "1.5",
+			opts: FormatOptions{Unit: Amount, Locale: Locale{Group: "'"}},
+			err:  ErrInvalidLocale,
+		},
+		{
+			name: "grouping without group separator",
+			s:    "1,5",
+			opts: FormatOptions{Unit: Amount, Group: true, Locale: Locale{Decimal: ","}},
+			err:  ErrInvalidLocale,
+		},
+		{
+			name: "excess precision",
+			s:    "0,000000001",
+			opts: FormatOptions{Unit: Amount, Locale: LocaleEuropean},
+			err:  ErrPrecision,
+		},
+	}
+
+	for _, test := range tests {
+		_, err := ParseAmountWith(test.s, test.opts)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+		}
+	}
+
+	// An invalid locale is formatted with LocaleDefault.
+	opts := FormatOptions{Unit: Amount, Group: true, Locale: Locale{Decimal: ",", Group: ","}}
+	if s := AmountType(123456789012).FormatWith(opts); s != "1,234.56789012" {
+		t.Errorf("expected the default locale, got %q", s)
+	}
+
+	// Grouping is optional when parsing.
+	a, err := ParseAmountWith("1234,5", FormatOptions{Unit: Amount, Group: true, Locale: LocaleEuropean})
+	if err != nil || a != 1234e8+5e7 {
+		t.Errorf("expected %d, got %d (%v)", AmountType(1234e8+5e7), a, err)
+	}
+}
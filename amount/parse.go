@@ -0,0 +1,178 @@
+package amount
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrSyntax indicates the string is not a decimal number.
+	ErrSyntax = errors.New("invalid syntax")
+
+	// ErrPrecision indicates the string has more decimals than the unit
+	// allows, so it isn't a whole number of sats.
+	ErrPrecision = errors.New("excess precision")
+
+	// ErrRange indicates the amount doesn't fit in an AmountType.
+	ErrRange = errors.New("value out of range")
+)
+
+// ParseError describes a failure to parse an amount.  Err is one of
+// ErrSyntax, ErrPrecision or ErrRange.
+type ParseError struct {
+	Input string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	return "amount: parsing " + strconv.Quote(e.Input) + ": " + e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseOptions enables optional syntax when parsing amounts.
+type ParseOptions struct {
+	// AllowThousands accepts commas between groups of three digits in the
+	// integer part, such as "1,000,000.5".
+	AllowThousands bool
+
+	// AllowExponent accepts a decimal exponent, such as "1.5e3".
+	AllowExponent bool
+}
+
+// ParseAmount parses a decimal string expressed in the given unit into an
+// exact amount of sats, without going through floating point.  The string is
+// an optional sign followed by digits with an optional decimal point.
+// Decimals beyond one sat are rejected rather than rounded.
+func ParseAmount(s string, u AmountUnit) (AmountType, error) {
+	return DefaultDenomination.ParseAmountOptions(s, u, ParseOptions{})
+}
+
+// ParseAmountOptions parses a decimal string like ParseAmount, additionally
+// accepting the syntax enabled by opts.
+func ParseAmountOptions(s string, u AmountUnit, opts ParseOptions) (AmountType, error) {
+	return DefaultDenomination.ParseAmountOptions(s, u, opts)
+}
+
+// ParseAmount parses a decimal string expressed in the given unit into an
+// exact amount of sats of the denomination.
+func (d *Denomination) ParseAmount(s string, u AmountUnit) (AmountType, error) {
+	return d.ParseAmountOptions(s, u, ParseOptions{})
+}
+
+// ParseAmountOptions parses a decimal string like ParseAmount, additionally
+// accepting the syntax enabled by opts.
+func (d *Denomination) ParseAmountOptions(s string, u AmountUnit, opts ParseOptions) (AmountType, error) {
+	a, err := parseAmount(s, int(u)+d.Decimals, opts)
+	if err != nil {
+		return 0, &ParseError{Input: s, Err: err}
+	}
+	return a, nil
+}
+
+// parseAmount parses a decimal string into sats, where one sat is
+// 10^-decimals of the string's unit.
+func parseAmount(s string, decimals int, opts ParseOptions) (AmountType, error) {
+	rest := s
+	neg := false
+	if rest != "" && (rest[0] == '+' || rest[0] == '-') {
+		neg = rest[0] == '-'
+		rest = rest[1:]
+	}
+
+	mantissa, exp := rest, 0
+	if i := strings.IndexAny(rest, "eE"); i >= 0 {
+		if !opts.AllowExponent {
+			return 0, ErrSyntax
+		}
+		mantissa = rest[:i]
+		e, err := strconv.ParseInt(rest[i+1:], 10, 32)
+		if err != nil {
+			if errors.Is(err, strconv.ErrRange) {
+				return 0, ErrRange
+			}
+			return 0, ErrSyntax
+		}
+		exp = int(e)
+	}
+
+	intPart, fracPart := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		intPart, fracPart = mantissa[:i], mantissa[i+1:]
+	}
+	if opts.AllowThousands && strings.IndexByte(intPart, ',') >= 0 {
+		var ok bool
+		if intPart, ok = stripThousands(intPart); !ok {
+			return 0, ErrSyntax
+		}
+	}
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, ErrSyntax
+	}
+
+	// Move the decimal point of the digits to express the amount in sats.
+	digits := intPart + fracPart
+	point := len(intPart) + decimals + exp
+
+	// Every digit after the point must be zero.
+	for i := len(digits) - 1; i >= 0 && i >= point; i-- {
+		if digits[i] != '0' {
+			return 0, ErrPrecision
+		}
+	}
+
+	first := strings.IndexFunc(digits, func(r rune) bool { return r != '0' })
+	if first < 0 || first >= point {
+		return 0, nil
+	}
+	if point-first > 19 {
+		return 0, ErrRange
+	}
+
+	var sats uint64
+	for i := first; i < point; i++ {
+		d := uint64(0)
+		if i < len(digits) {
+			d = uint64(digits[i] - '0')
+		}
+		sats = sats*10 + d
+	}
+
+	switch {
+	case neg && sats > math.MaxInt64+1:
+		return 0, ErrRange
+	case neg:
+		return AmountType(-int64(sats-1) - 1), nil
+	case sats > math.MaxInt64:
+		return 0, ErrRange
+	}
+	return AmountType(sats), nil
+}
+
+// stripThousands removes the commas of a number grouped in thousands.
+func stripThousands(s string) (string, bool) {
+	groups := strings.Split(s, ",")
+	if len(groups[0]) < 1 || len(groups[0]) > 3 {
+		return "", false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+// isDigits reports whether s consists of ASCII digits only.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+package amount
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		unit     AmountUnit
+		opts     ParseOptions
+		expected AmountType
+		err      error
+	}{
+		// Positive tests.
+		{name: "zero", s: "0", unit: Amount, expected: 0},
+		{name: "negative zero", s: "-0.0", unit: Amount, expected: 0},
+		{name: "one", s: "1", unit: Amount, expected: 1e8},
+		{name: "explicit plus", s: "+1", unit: Amount, expected: 1e8},
+		{name: "one sat", s: "0.00000001", unit: Amount, expected: 1},
+		{name: "no integer part", s: ".5", unit: Amount, expected: 5e7},
+		{name: "no fraction digits", s: "5.", unit: Amount, expected: 5e8},
+		{name: "trailing zeros", s: "1.2345678900000", unit: Amount, expected: 123456789},
+		{name: "leading zeros", s: "000021", unit: Amount, expected: 21e8},
+		{name: "max producible", s: "21000000", unit: Amount, expected: MaxSats},
+		{name: "min producible", s: "-21000000", unit: Amount, expected: -MaxSats},
+		{name: "not rounded by floats", s: "0.29", unit: Amount, expected: 29e6},
+		{name: "mega", s: "1.5", unit: AmountMega, expected: 15e13},
+		{name: "kilo", s: "0.00000000001", unit: AmountKilo, expected: 1},
+		{name: "milli", s: "123.45678", unit: AmountMilli, expected: 12345678},
+		{name: "micro", s: "1.23", unit: AmountMicro, expected: 123},
+		{name: "sats", s: "123", unit: AmountSats, expected: 123},
+		{name: "custom unit", s: "1.2345678", unit: AmountUnit(-1), expected: 12345678},
+		{name: "max int64", s: "9223372036854775807", unit: AmountSats, expected: math.MaxInt64},
+		{name: "min int64", s: "-9223372036854775808", unit: AmountSats, expected: math.MinInt64},
+		{
+			name:     "thousands",
+			s:        "1,234,567.5",
+			unit:     Amount,
+			opts:     ParseOptions{AllowThousands: true},
+			expected: 1234567e8 + 5e7,
+		},
+		{
+			name:     "exponent",
+			s:        "1.5e3",
+			unit:     Amount,
+			opts:     ParseOptions{AllowExponent: true},
+			expected: 1500e8,
+		},
+		{
+			name:     "negative exponent",
+			s:        "-12E-8",
+			unit:     Amount,
+			opts:     ParseOptions{AllowExponent: true},
+			expected: -12,
+		},
+		{
+			name:     "zero with large exponent",
+			s:        "0e1000",
+			unit:     Amount,
+			opts:     ParseOptions{AllowExponent: true},
+			expected: 0,
+		},
+
+		// Negative tests.
+		{name: "empty", s: "", unit: Amount, err: ErrSyntax},
+		{name: "sign only", s: "-", unit: Amount, err: ErrSyntax},
+		{name: "point only", s: ".", unit: Amount, err: ErrSyntax},
+		{name: "two points", s: "1.2.3", unit: Amount, err: ErrSyntax},
+		{name: "two signs", s: "--1", unit: Amount, err: ErrSyntax},
+		{name: "whitespace", s: " 1", unit: Amount, err: ErrSyntax},
+		{name: "letters", s: "1btc", unit: Amount, err: ErrSyntax},
+		{name: "underscore", s: "1_000", unit: Amount, err: ErrSyntax},
+		{name: "infinity", s: "Inf", unit: Amount, err: ErrSyntax},
+		{name: "nan", s: "NaN", unit: Amount, err: ErrSyntax},
+		{name: "hex", s: "0x10", unit: Amount, err: ErrSyntax},
+		{name: "exponent not enabled", s: "1e3", unit: Amount, err: ErrSyntax},
+		{name: "thousands not enabled", s: "1,000", unit: Amount, err: ErrSyntax},
+		{
+			name: "misplaced thousands",
+			s:    "1,00,000",
+			unit: Amount,
+			opts: ParseOptions{AllowThousands: true},
+			err:  ErrSyntax,
+		},
+		{
+			name: "thousands in fraction",
+			s:    "0.123,456",
+			unit: Amount,
+			opts: ParseOptions{AllowThousands: true},
+			err:  ErrSyntax,
+		},
+		{
+			name: "empty exponent",
+			s:    "1e",
+			unit: Amount,
+			opts: ParseOptions{AllowExponent: true},
+			err:  ErrSyntax,
+		},
+		{name: "below one sat", s: "0.000000001", unit: Amount, err: ErrPrecision},
+		{name: "fractional sats", s: "1.5", unit: AmountSats, err: ErrPrecision},
+		{name: "fractional micro", s: "0.001", unit: AmountMicro, err: ErrPrecision},
+		{
+			name: "exponent below one sat",
+			s:    "1e-9",
+			unit: Amount,
+			opts: ParseOptions{AllowExponent: true},
+			err:  ErrPrecision,
+		},
+		{name: "exceeds max int64", s: "9223372036854775808", unit: AmountSats, err: ErrRange},
+		{name: "exceeds min int64", s: "-9223372036854775809", unit: AmountSats, err: ErrRange},
+		{name: "many digits", s: "100000000000000000000000", unit: Amount, err: ErrRange},
+		{
+			name: "large exponent",
+			s:    "1e1000",
+			unit: Amount,
+			opts: ParseOptions{AllowExponent: true},
+			err:  ErrRange,
+		},
+		{
+			name: "exponent overflow",
+			s:    "1e99999999999",
+			unit: Amount,
+			opts: ParseOptions{AllowExponent: true},
+			err:  ErrRange,
+		},
+	}
+
+	for _, test := range tests {
+		a, err := ParseAmountOptions(test.s, test.unit, test.opts)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+				continue
+			}
+			var perr *ParseError
+			if !errors.As(err, &perr) || perr.Input != test.s {
+				t.Errorf("%v: expected ParseError for %q, got %v", test.name, test.s, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", test.name, err)
+			continue
+		}
+		if a != test.expected {
+			t.Errorf("%v: expected %d sats, got %d", test.name, test.expected, a)
+		}
+	}
+}
+
+func TestParseAmountFormatRoundTrip(t *testing.T) {
+	units := []AmountUnit{AmountMega, AmountKilo, Amount, AmountMilli, AmountMicro, AmountSats}
+	amounts := []AmountType{0, 1, -1, 12345678, 29e6, MaxSats, -MaxSats}
+	for _, u := range units {
+		for _, a := range amounts {
+			s := a.Format(u)
+			parsed, err := ParseAmount(s, u)
+			if err != nil {
+				t.Errorf("%v in %v: unexpected error %v", a, u, err)
+				continue
+			}
+			if parsed != a {
+				t.Errorf("%v in %v: parsed %q as %d sats", a, u, s, parsed)
+			}
+		}
+	}
+}
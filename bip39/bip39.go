@@ -6,9 +6,9 @@ import (
 	"crypto/sha512"
 	"encoding/binary"
 	"errors"
-	"fmt"
-	"github.com/grupokindynos/ogen-utils/bip39/words"
+	"github.com/grupokindynos/ogen-utils/secret"
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
 	"math/big"
 	"strings"
 )
@@ -20,29 +20,8 @@ var (
 	bigOne          = big.NewInt(1)
 	bigTwo          = big.NewInt(2)
 
-	// used to isolate the checksum bits from the entropy+checksum byte array
-	wordLengthChecksumMasksMapping = map[int]*big.Int{
-		12: big.NewInt(15),
-		15: big.NewInt(31),
-		18: big.NewInt(63),
-		21: big.NewInt(127),
-		24: big.NewInt(255),
-	}
-	// used to use only the desired x of 8 available checksum bits.
-	// 256 bit (word length 24) requires all 8 bits of the checksum,
-	// and thus no shifting is needed for it (we would get a divByZero crash if we did)
-	wordLengthChecksumShiftMapping = map[int]*big.Int{
-		12: big.NewInt(16),
-		15: big.NewInt(8),
-		18: big.NewInt(4),
-		21: big.NewInt(2),
-	}
-
-	// wordList is the set of words to use
-	wordList []string
-
-	// wordMap is a reverse lookup map for wordList
-	wordMap map[string]int
+	// defaultLanguage is the language used by the package-level functions.
+	defaultLanguage = English
 )
 
 var (
@@ -62,29 +41,26 @@ var (
 	ErrChecksumIncorrect = errors.New("Checksum incorrect")
 )
 
-func init() {
-	SetWordList(words.English)
-}
-
-// SetWordList sets the list of words to use for mnemonics. Currently the list
-// that is set is used package-wide.
+// SetWordList sets the list of words used by the package-level functions,
+// which default to words.English.
+//
+// NOTE: This changes the behavior of the package-level functions for every
+// caller and is not safe for concurrent use.  Code working with more than one
+// language should use a Language value such as Spanish or one created by
+// NewLanguage instead.
 func SetWordList(list []string) {
-	wordList = list
-	wordMap = map[string]int{}
-	for i, v := range wordList {
-		wordMap[v] = i
-	}
+	defaultLanguage = NewLanguage(list)
 }
 
-// GetWordList gets the list of words to use for mnemonics.
+// GetWordList gets the list of words used by the package-level functions.
 func GetWordList() []string {
-	return wordList
+	return defaultLanguage.WordList()
 }
 
-// GetWordIndex gets word index in wordMap.
+// GetWordIndex gets the index of a word in the list of words used by the
+// package-level functions.
 func GetWordIndex(word string) (int, bool) {
-	idx, ok := wordMap[word]
-	return idx, ok
+	return defaultLanguage.WordIndex(word)
 }
 
 // NewEntropy will create random entropy bytes
@@ -105,57 +81,34 @@ func NewEntropy(bitSize int) ([]byte, error) {
 // EntropyFromMnemonic takes a mnemonic generated by this library,
 // and returns the input entropy used to generate the given mnemonic.
 // An error is returned if the given mnemonic is invalid.
+//
+// The mnemonic is decoded using the list of words set by SetWordList.
 func EntropyFromMnemonic(mnemonic string) ([]byte, error) {
-	mnemonicSlice, isValid := splitMnemonicWords(mnemonic)
-	if !isValid {
-		return nil, ErrInvalidMnemonic
-	}
-
-	// Decode the words into a big.Int.
-	b := big.NewInt(0)
-	for _, v := range mnemonicSlice {
-		index, found := wordMap[v]
-		if found == false {
-			return nil, fmt.Errorf("word `%v` not found in reverse map", v)
-		}
-		var wordBytes [2]byte
-		binary.BigEndian.PutUint16(wordBytes[:], uint16(index))
-		b = b.Mul(b, shift11BitsMask)
-		b = b.Or(b, big.NewInt(0).SetBytes(wordBytes[:]))
-	}
-
-	// Build and add the checksum to the big.Int.
-	checksum := big.NewInt(0)
-	checksumMask := wordLengthChecksumMasksMapping[len(mnemonicSlice)]
-	checksum = checksum.And(b, checksumMask)
-
-	b.Div(b, big.NewInt(0).Add(checksumMask, bigOne))
-
-	// The entropy is the underlying bytes of the big.Int. Any upper bytes of
-	// all 0's are not returned so we pad the beginning of the slice with empty
-	// bytes if necessary.
-	entropy := b.Bytes()
-	entropy = padByteSlice(entropy, len(mnemonicSlice)/3*4)
-
-	// Generate the checksum and compare with the one we got from the mneomnic.
-	entropyChecksumBytes := computeChecksum(entropy)
-	entropyChecksum := big.NewInt(int64(entropyChecksumBytes[0]))
-	if l := len(mnemonicSlice); l != 24 {
-		checksumShift := wordLengthChecksumShiftMapping[l]
-		entropyChecksum.Div(entropyChecksum, checksumShift)
-	}
+	return defaultLanguage.EntropyFromMnemonic(mnemonic)
+}
 
-	if checksum.Cmp(entropyChecksum) != 0 {
-		return nil, ErrChecksumIncorrect
+// EntropyFromMnemonic takes a mnemonic in this language and returns the input
+// entropy used to generate the given mnemonic.
+// An error is returned if the given mnemonic is invalid.
+func (l *Language) EntropyFromMnemonic(mnemonic string) ([]byte, error) {
+	m, err := l.ParseMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
 	}
-
-	return entropy, nil
+	return m.Entropy, nil
 }
 
 // NewMnemonic will return a string consisting of the mnemonic words for
-// the given entropy.
+// the given entropy, taken from the list of words set by SetWordList.
 // If the provide entropy is invalid, an error will be returned.
 func NewMnemonic(entropy []byte) (string, error) {
+	return defaultLanguage.NewMnemonic(entropy)
+}
+
+// NewMnemonic will return a string consisting of the mnemonic words in this
+// language for the given entropy.
+// If the provide entropy is invalid, an error will be returned.
+func (l *Language) NewMnemonic(entropy []byte) (string, error) {
 	// Compute some lengths for convenience.
 	entropyBitLength := len(entropy) * 8
 	checksumBitLength := entropyBitLength / 32
@@ -169,6 +122,7 @@ func NewMnemonic(entropy []byte) (string, error) {
 
 	// Add checksum to entropy.
 	entropy = addChecksum(entropy)
+	defer secret.Zero(entropy)
 
 	// Break entropy up into sentenceLength chunks of 11 bits.
 	// For each word AND mask the rightmost 11 bits and find the word at that index.
@@ -177,12 +131,14 @@ func NewMnemonic(entropy []byte) (string, error) {
 
 	// Entropy as an int so we can bitmask without worrying about bytes slices.
 	entropyInt := new(big.Int).SetBytes(entropy)
+	defer zeroBigInt(entropyInt)
 
 	// Slice to hold words in.
 	words := make([]string, sentenceLength)
 
 	// Throw away big.Int for AND masking.
 	word := big.NewInt(0)
+	defer zeroBigInt(word)
 
 	for i := sentenceLength - 1; i >= 0; i-- {
 		// Get 11 right most bits and bitshift 11 to the right for next time.
@@ -193,65 +149,51 @@ func NewMnemonic(entropy []byte) (string, error) {
 		wordBytes := padByteSlice(word.Bytes(), 2)
 
 		// Convert bytes to an index and add that word to the list.
-		words[i] = wordList[binary.BigEndian.Uint16(wordBytes)]
+		words[i] = l.wordList[binary.BigEndian.Uint16(wordBytes)]
 	}
 
-	return strings.Join(words, " "), nil
+	return strings.Join(words, l.separator), nil
 }
 
 // MnemonicToByteArray takes a mnemonic string and turns it into a byte array
 // suitable for creating another mnemonic.
 // An error is returned if the mnemonic is invalid.
+//
+// The mnemonic is decoded using the list of words set by SetWordList.
 func MnemonicToByteArray(mnemonic string, raw ...bool) ([]byte, error) {
-	var (
-		mnemonicSlice    = strings.Split(mnemonic, " ")
-		entropyBitSize   = len(mnemonicSlice) * 11
-		checksumBitSize  = entropyBitSize % 32
-		fullByteSize     = (entropyBitSize-checksumBitSize)/8 + 1
-		checksumByteSize = fullByteSize - (fullByteSize % 4)
-	)
-
-	// Pre validate that the mnemonic is well formed and only contains words that
-	// are present in the word list.
-	if !IsMnemonicValid(mnemonic) {
-		return nil, ErrInvalidMnemonic
-	}
-
-	// Convert word indices to a big.Int representing the entropy.
-	checksummedEntropy := big.NewInt(0)
-	modulo := big.NewInt(2048)
-	for _, v := range mnemonicSlice {
-		index := big.NewInt(int64(wordMap[v]))
-		checksummedEntropy.Mul(checksummedEntropy, modulo)
-		checksummedEntropy.Add(checksummedEntropy, index)
-	}
-
-	// Calculate the unchecksummed entropy so we can validate that the checksum is
-	// correct.
-	checksumModulo := big.NewInt(0).Exp(bigTwo, big.NewInt(int64(checksumBitSize)), nil)
-	rawEntropy := big.NewInt(0).Div(checksummedEntropy, checksumModulo)
-
-	// Convert big.Ints to byte padded byte slices.
-	rawEntropyBytes := padByteSlice(rawEntropy.Bytes(), checksumByteSize)
-	checksummedEntropyBytes := padByteSlice(checksummedEntropy.Bytes(), fullByteSize)
+	return defaultLanguage.MnemonicToByteArray(mnemonic, raw...)
+}
 
-	// Validate that the checksum is correct.
-	newChecksummedEntropyBytes := padByteSlice(addChecksum(rawEntropyBytes), fullByteSize)
-	if !compareByteSlices(checksummedEntropyBytes, newChecksummedEntropyBytes) {
-		return nil, ErrChecksumIncorrect
+// MnemonicToByteArray takes a mnemonic string in this language and turns it
+// into a byte array suitable for creating another mnemonic.
+// An error is returned if the mnemonic is invalid.
+func (l *Language) MnemonicToByteArray(mnemonic string, raw ...bool) ([]byte, error) {
+	m, err := l.ParseMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
 	}
 
 	if len(raw) > 0 && raw[0] {
-		return rawEntropyBytes, nil
+		return m.Entropy, nil
 	}
 
+	// The checksum bits follow the entropy, padded to a whole byte.
+	checksummedEntropyBytes := padByteSlice(addChecksum(m.Entropy), len(m.Entropy)+1)
+	secret.Zero(m.Entropy)
 	return checksummedEntropyBytes, nil
 }
 
 // NewSeedWithErrorChecking creates a hashed seed output given the mnemonic string and a password.
-// An error is returned if the mnemonic is not convertible to a byte array.
+// An error is returned if the mnemonic is not convertible to a byte array
+// using the list of words set by SetWordList.
 func NewSeedWithErrorChecking(mnemonic string, password string) ([]byte, error) {
-	_, err := MnemonicToByteArray(mnemonic)
+	return defaultLanguage.NewSeedWithErrorChecking(mnemonic, password)
+}
+
+// NewSeedWithErrorChecking creates a hashed seed output given the mnemonic string and a password.
+// An error is returned if the mnemonic is not a valid mnemonic in this language.
+func (l *Language) NewSeedWithErrorChecking(mnemonic string, password string) ([]byte, error) {
+	_, err := l.MnemonicToByteArray(mnemonic)
 	if err != nil {
 		return nil, err
 	}
@@ -260,15 +202,44 @@ func NewSeedWithErrorChecking(mnemonic string, password string) ([]byte, error)
 
 // NewSeed creates a hashed seed output given a provided string and password.
 // No checking is performed to validate that the string provided is a valid mnemonic.
+//
+// Both the mnemonic and the password are normalized to Unicode NFKD form as
+// required by BIP-39 before being hashed.
+//
+// The returned seed is secret material and should be cleared by the caller
+// once it is no longer needed.  NewSeedBuffer returns the seed wrapped in a
+// secret.Buffer for that purpose.
 func NewSeed(mnemonic string, password string) []byte {
-	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+password), 2048, 64, sha512.New)
+	mnemonicBytes := norm.NFKD.Bytes([]byte(mnemonic))
+	defer secret.Zero(mnemonicBytes)
+
+	// Build the salt in a byte slice rather than concatenating strings so
+	// the copy of the password can be cleared.
+	salt := norm.NFKD.AppendString([]byte("mnemonic"), password)
+	defer secret.Zero(salt)
+
+	return pbkdf2.Key(mnemonicBytes, salt, 2048, 64, sha512.New)
+}
+
+// NewSeedBuffer creates a hashed seed output like NewSeed, but returns it in a
+// secret.Buffer which clears the seed when destroyed.
+func NewSeedBuffer(mnemonic string, password string) *secret.Buffer {
+	return secret.NewBufferFrom(NewSeed(mnemonic, password))
 }
 
 // IsMnemonicValid attempts to verify that the provided mnemonic is valid.
 // Validity is determined by both the number of words being appropriate,
-// and that all the words in the mnemonic are present in the word list.
+// and that all the words in the mnemonic are present in the list of words set
+// by SetWordList.
 func IsMnemonicValid(mnemonic string) bool {
-	_, err := EntropyFromMnemonic(mnemonic)
+	return defaultLanguage.IsMnemonicValid(mnemonic)
+}
+
+// IsMnemonicValid attempts to verify that the provided mnemonic is valid.
+// Validity is determined by both the number of words being appropriate,
+// and that all the words in the mnemonic are present in this language.
+func (l *Language) IsMnemonicValid(mnemonic string) bool {
+	_, err := l.EntropyFromMnemonic(mnemonic)
 	return err == nil
 }
 
@@ -286,6 +257,7 @@ func addChecksum(data []byte) []byte {
 	// and then set the (new) right most bit equal to checksum bit at that index
 	// staring from the left
 	dataBigInt := new(big.Int).SetBytes(data)
+	defer zeroBigInt(dataBigInt)
 	for i := uint(0); i < checksumBitLength; i++ {
 		// Bitshift 1 left
 		dataBigInt.Mul(dataBigInt, bigTwo)
@@ -305,6 +277,16 @@ func computeChecksum(data []byte) []byte {
 	return hasher.Sum(nil)
 }
 
+// zeroBigInt clears the words backing a big.Int that held secret material and
+// sets it to zero.
+func zeroBigInt(b *big.Int) {
+	words := b.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+	b.SetInt64(0)
+}
+
 // validateEntropyBitSize ensures that entropy is the correct size for being a
 // mnemonic.
 func validateEntropyBitSize(bitSize int) error {
@@ -340,16 +322,9 @@ func compareByteSlices(a, b []byte) bool {
 	return true
 }
 
-func splitMnemonicWords(mnemonic string) ([]string, bool) {
-	// Create a list of all the words in the mnemonic sentence
-	words := strings.Fields(mnemonic)
-
-	// Get num of words
-	numOfWords := len(words)
-
+// validWordCount returns whether or not a mnemonic may have the given number
+// of words.
+func validWordCount(numOfWords int) bool {
 	// The number of words should be 12, 15, 18, 21 or 24
-	if numOfWords%3 != 0 || numOfWords < 12 || numOfWords > 24 {
-		return nil, false
-	}
-	return words, true
+	return numOfWords%3 == 0 && numOfWords >= 12 && numOfWords <= 24
 }
@@ -3,6 +3,7 @@ package bip39
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"github.com/grupokindynos/ogen-utils/bip39/words"
 	"testing"
 )
@@ -18,7 +19,7 @@ func TestGetWordList(t *testing.T) {
 }
 
 func TestGetWordIndex(t *testing.T) {
-	for expectedIdx, word := range wordList {
+	for expectedIdx, word := range GetWordList() {
 		actualIdx, ok := GetWordIndex(word)
 		assertTrue(t, ok)
 		assertEqual(t, actualIdx, expectedIdx)
@@ -48,6 +49,16 @@ func TestNewMnemonic(t *testing.T) {
 	}
 }
 
+func TestNewSeedBuffer(t *testing.T) {
+	for _, vector := range testVectors() {
+		buf := NewSeedBuffer(vector.mnemonic, "TREZOR")
+		assertEqualString(t, vector.seed, hex.EncodeToString(buf.Bytes()))
+
+		buf.Destroy()
+		assertEqual(t, 0, buf.Len())
+	}
+}
+
 func TestNewMnemonicInvalidEntropy(t *testing.T) {
 	_, err := NewMnemonic([]byte{})
 	assertNotNil(t, err)
@@ -78,7 +89,7 @@ func TestMnemonicToByteArrayInvalidMnemonic(t *testing.T) {
 
 	_, err := MnemonicToByteArray("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon yellow")
 	assertNotNil(t, err)
-	assertEqual(t, err, ErrInvalidMnemonic)
+	assertTrue(t, errors.Is(err, ErrInvalidMnemonic))
 }
 
 func TestNewEntropy(t *testing.T) {
@@ -250,7 +261,7 @@ func TestEntropyFromMnemonic256(t *testing.T) {
 
 func TestEntropyFromMnemonicInvalidChecksum(t *testing.T) {
 	_, err := EntropyFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon yellow")
-	assertEqual(t, ErrChecksumIncorrect, err)
+	assertTrue(t, errors.Is(err, ErrChecksumIncorrect))
 }
 
 func TestEntropyFromMnemonicInvalidMnemonicSize(t *testing.T) {
@@ -260,7 +271,7 @@ func TestEntropyFromMnemonicInvalidMnemonicSize(t *testing.T) {
 		"a a a a a a a a a a a a a a", // Not multiple of 3
 	} {
 		_, err := EntropyFromMnemonic(mnemonic)
-		assertEqual(t, ErrInvalidMnemonic, err)
+		assertTrue(t, errors.Is(err, ErrInvalidMnemonic))
 	}
 }
 
@@ -0,0 +1,260 @@
+package bip39
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"hash"
+	"runtime"
+	"sync"
+
+	"github.com/grupokindynos/ogen-utils/secret"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// DefaultSeedIterations is the number of PBKDF2 iterations required by
+	// BIP-39.
+	DefaultSeedIterations = 2048
+
+	// DefaultSeedLen is the length in bytes of a BIP-39 seed.
+	DefaultSeedLen = 64
+
+	// DefaultSaltPrefix precedes the password in the BIP-39 salt.
+	DefaultSaltPrefix = "mnemonic"
+
+	// kdfCheckInterval is the number of iterations between checks for the
+	// cancellation of the context.
+	kdfCheckInterval = 128
+)
+
+// KDF is a key derivation function.  Implementations should stop and return
+// ctx.Err() soon after ctx is done.
+type KDF func(ctx context.Context, password, salt []byte, iterations, keyLen int) ([]byte, error)
+
+// PBKDF2 returns a PBKDF2 key derivation function using HMAC with the given
+// hash function.  The derivation checks for the cancellation of its context
+// while iterating.
+func PBKDF2(h func() hash.Hash) KDF {
+	return func(ctx context.Context, password, salt []byte, iterations, keyLen int) ([]byte, error) {
+		return pbkdf2Key(ctx, h, password, salt, iterations, keyLen)
+	}
+}
+
+// pbkdf2Key derives a key as specified by RFC 8018, returning early if the
+// context is done.
+func pbkdf2Key(ctx context.Context, h func() hash.Hash, password, salt []byte, iterations, keyLen int) ([]byte, error) {
+	prf := hmac.New(h, password)
+	hashLen := prf.Size()
+	numBlocks := (keyLen + hashLen - 1) / hashLen
+
+	var buf [4]byte
+	dk := make([]byte, 0, numBlocks*hashLen)
+	u := make([]byte, hashLen)
+	defer secret.Zero(u)
+	for block := 1; block <= numBlocks; block++ {
+		// T_block = U_1 ^ U_2 ^ ... ^ U_iterations, where
+		// U_1 = PRF(password, salt || uint32(block)) and
+		// U_n = PRF(password, U_(n-1)).
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(buf[:], uint32(block))
+		prf.Write(buf[:])
+		dk = prf.Sum(dk)
+		t := dk[len(dk)-hashLen:]
+		copy(u, t)
+
+		for n := 2; n <= iterations; n++ {
+			if n%kdfCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					secret.Zero(dk)
+					return nil, err
+				}
+			}
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for i := range u {
+				t[i] ^= u[i]
+			}
+		}
+	}
+	return dk[:keyLen], nil
+}
+
+// SeedDeriver derives seeds from mnemonics.  The zero value derives BIP-39
+// seeds, exactly like NewSeed.  Its fields must not be modified while it is in
+// use, but a SeedDeriver may otherwise be used concurrently.
+type SeedDeriver struct {
+	// Iterations is the number of iterations of the KDF.  Zero means
+	// DefaultSeedIterations.
+	Iterations int
+
+	// SeedLen is the length of the derived seeds in bytes.  Zero means
+	// DefaultSeedLen.
+	SeedLen int
+
+	// SaltPrefix precedes the password in the salt.  Empty means
+	// DefaultSaltPrefix.
+	SaltPrefix string
+
+	// KDF is the key derivation function.  Nil means PBKDF2 with
+	// HMAC-SHA512.
+	KDF KDF
+
+	// Workers is the number of goroutines used by SeedBatch and
+	// DeriveEach.  Zero means runtime.NumCPU().
+	Workers int
+}
+
+// defaultKDF is the key derivation function of BIP-39.
+var defaultKDF = PBKDF2(sha512.New)
+
+func (d *SeedDeriver) iterations() int {
+	if d.Iterations > 0 {
+		return d.Iterations
+	}
+	return DefaultSeedIterations
+}
+
+func (d *SeedDeriver) seedLen() int {
+	if d.SeedLen > 0 {
+		return d.SeedLen
+	}
+	return DefaultSeedLen
+}
+
+func (d *SeedDeriver) saltPrefix() string {
+	if d.SaltPrefix != "" {
+		return d.SaltPrefix
+	}
+	return DefaultSaltPrefix
+}
+
+func (d *SeedDeriver) kdf() KDF {
+	if d.KDF != nil {
+		return d.KDF
+	}
+	return defaultKDF
+}
+
+func (d *SeedDeriver) workers() int {
+	if d.Workers > 0 {
+		return d.Workers
+	}
+	return runtime.NumCPU()
+}
+
+// Seed derives the seed of a mnemonic and password.  Like NewSeed, no checking
+// is performed to validate the mnemonic, and both the mnemonic and password
+// are normalized to Unicode NFKD form.
+func (d *SeedDeriver) Seed(mnemonic, password string) []byte {
+	seed, _ := d.SeedContext(context.Background(), mnemonic, password)
+	return seed
+}
+
+// SeedContext derives the seed of a mnemonic and password like Seed, but
+// stops and returns ctx.Err() once the context is done.
+func (d *SeedDeriver) SeedContext(ctx context.Context, mnemonic, password string) ([]byte, error) {
+	mnemonicBytes := norm.NFKD.Bytes([]byte(mnemonic))
+	defer secret.Zero(mnemonicBytes)
+	return d.derive(ctx, mnemonicBytes, password)
+}
+
+// derive derives the seed of an already normalized mnemonic.
+func (d *SeedDeriver) derive(ctx context.Context, mnemonic []byte, password string) ([]byte, error) {
+	salt := norm.NFKD.AppendString([]byte(d.saltPrefix()), password)
+	defer secret.Zero(salt)
+	return d.kdf()(ctx, mnemonic, salt, d.iterations(), d.seedLen())
+}
+
+// DeriveEach derives the seed of the mnemonic with each of the passwords using
+// a pool of worker goroutines, and calls fn with the index of the password and
+// its seed.  The seed is cleared once fn returns, so fn must copy it to keep
+// it.
+//
+// fn is called concurrently from the workers.  When fn returns an error or the
+// context is done, the remaining derivations are abandoned and the error is
+// returned.  Returning an error from fn is the way to stop early once a
+// password is found.
+func (d *SeedDeriver) DeriveEach(ctx context.Context, mnemonic string, passwords []string, fn func(index int, seed []byte) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	mnemonicBytes := norm.NFKD.Bytes([]byte(mnemonic))
+	defer secret.Zero(mnemonicBytes)
+
+	indices := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	workers := d.workers()
+	if workers > len(passwords) {
+		workers = len(passwords)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				seed, err := d.derive(ctx, mnemonicBytes, passwords[i])
+				if err != nil {
+					fail(err)
+					return
+				}
+				err = fn(i, seed)
+				secret.Zero(seed)
+				if err != nil {
+					fail(err)
+					return
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := range passwords {
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indices)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	// The context may have been cancelled by the caller while feeding.
+	return ctx.Err()
+}
+
+// SeedBatch derives the seed of the mnemonic with each of the passwords using
+// a pool of worker goroutines.  The seeds are returned in the order of the
+// passwords.  When the context is done, the seeds derived so far are cleared
+// and ctx.Err() is returned.
+func (d *SeedDeriver) SeedBatch(ctx context.Context, mnemonic string, passwords []string) ([][]byte, error) {
+	seeds := make([][]byte, len(passwords))
+	err := d.DeriveEach(ctx, mnemonic, passwords, func(index int, seed []byte) error {
+		seeds[index] = append([]byte(nil), seed...)
+		return nil
+	})
+	if err != nil {
+		for _, seed := range seeds {
+			secret.Zero(seed)
+		}
+		return nil, err
+	}
+	return seeds, nil
+}
@@ -0,0 +1,133 @@
+package bip39
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+func TestSeedDeriverMatchesNewSeed(t *testing.T) {
+	var d SeedDeriver
+	for _, vector := range testVectors() {
+		seed := d.Seed(vector.mnemonic, "TREZOR")
+		assertEqualString(t, vector.seed, hex.EncodeToString(seed))
+	}
+}
+
+func TestPBKDF2(t *testing.T) {
+	kdf := PBKDF2(sha256.New)
+	for _, keyLen := range []int{1, 20, 32, 33, 100} {
+		for _, iterations := range []int{1, 2, 127, 128, 1000} {
+			expected := pbkdf2.Key([]byte("password"), []byte("salt"), iterations, keyLen, sha256.New)
+			actual, err := kdf(context.Background(), []byte("password"), []byte("salt"), iterations, keyLen)
+			assertNil(t, err)
+			assertEqualByteSlices(t, expected, actual)
+		}
+	}
+}
+
+func TestSeedDeriverOptions(t *testing.T) {
+	mnemonic := testVectors()[0].mnemonic
+	d := SeedDeriver{
+		Iterations: 4096,
+		SeedLen:    32,
+		SaltPrefix: "custom",
+	}
+	expected := pbkdf2.Key([]byte(mnemonic), []byte("customTREZOR"), 4096, 32, sha512.New)
+	assertEqualByteSlices(t, expected, d.Seed(mnemonic, "TREZOR"))
+
+	called := false
+	d.KDF = func(ctx context.Context, password, salt []byte, iterations, keyLen int) ([]byte, error) {
+		called = true
+		assertEqualString(t, mnemonic, string(password))
+		assertEqualString(t, "customTREZOR", string(salt))
+		assertEqual(t, 4096, iterations)
+		assertEqual(t, 32, keyLen)
+		return make([]byte, keyLen), nil
+	}
+	d.Seed(mnemonic, "TREZOR")
+	assertTrue(t, called)
+}
+
+func TestSeedDeriverContext(t *testing.T) {
+	d := SeedDeriver{Iterations: 1 << 30}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	seed, err := d.SeedContext(ctx, testVectors()[0].mnemonic, "TREZOR")
+	assertTrue(t, seed == nil)
+	assertEqual(t, context.DeadlineExceeded, err)
+	assertTrue(t, time.Since(start) < time.Second)
+}
+
+func TestSeedDeriverBatch(t *testing.T) {
+	d := SeedDeriver{Workers: 4}
+	mnemonic := testVectors()[0].mnemonic
+
+	passwords := make([]string, 20)
+	for i := range passwords {
+		passwords[i] = fmt.Sprintf("password %d", i)
+	}
+
+	seeds, err := d.SeedBatch(context.Background(), mnemonic, passwords)
+	assertNil(t, err)
+	assertEqual(t, len(passwords), len(seeds))
+	for i, seed := range seeds {
+		assertEqualByteSlices(t, NewSeed(mnemonic, passwords[i]), seed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	seeds, err = d.SeedBatch(ctx, mnemonic, passwords)
+	assertTrue(t, seeds == nil)
+	assertEqual(t, context.Canceled, err)
+}
+
+func TestSeedDeriverDeriveEachStop(t *testing.T) {
+	d := SeedDeriver{Workers: 2}
+	mnemonic := testVectors()[0].mnemonic
+
+	passwords := make([]string, 1000)
+	for i := range passwords {
+		passwords[i] = fmt.Sprintf("candidate %d", i)
+	}
+	passwords[10] = "TREZOR"
+	target := NewSeed(mnemonic, "TREZOR")
+
+	errFound := errors.New("found")
+	found := -1
+	err := d.DeriveEach(context.Background(), mnemonic, passwords, func(index int, seed []byte) error {
+		if compareByteSlices(seed, target) {
+			found = index
+			return errFound
+		}
+		return nil
+	})
+	assertEqual(t, errFound, err)
+	assertEqual(t, 10, found)
+}
+
+func BenchmarkSeedDeriverBatch(b *testing.B) {
+	var d SeedDeriver
+	mnemonic := testVectors()[0].mnemonic
+	passwords := make([]string, 64)
+	for i := range passwords {
+		passwords[i] = fmt.Sprintf("password %d", i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := d.SeedBatch(context.Background(), mnemonic, passwords); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package bip39
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ErrUnknownLanguage is returned when the words of a mnemonic don't all belong
+// to any single known language.
+var ErrUnknownLanguage = errors.New("mnemonic words do not belong to any known language")
+
+// AmbiguousLanguageError is returned when a mnemonic is valid in more than one
+// language with different entropy.
+//
+// Some words are shared between languages.  The English and French lists have
+// about a hundred words in common at different indices, so a mnemonic made only
+// of those words may pass the checksum in both languages while encoding
+// different entropy.
+type AmbiguousLanguageError struct {
+	// Languages are the languages the mnemonic is valid in.
+	Languages []*Language
+}
+
+func (e *AmbiguousLanguageError) Error() string {
+	names := make([]string, len(e.Languages))
+	for i, language := range e.Languages {
+		names[i] = language.Name()
+	}
+	return "mnemonic is valid in more than one language: " + strings.Join(names, ", ")
+}
+
+// DetectLanguage returns the language of the given mnemonic out of Languages.
+//
+// Every language containing all the words of the mnemonic is a candidate, and
+// the checksum of the mnemonic is verified under each candidate.  The single
+// language the mnemonic is valid in is returned.  A *WordCountError is
+// returned when the number of words is not supported, ErrUnknownLanguage is
+// returned when there are no candidates, ErrChecksumIncorrect is returned when
+// the checksum fails under every candidate and an *AmbiguousLanguageError is
+// returned when the mnemonic is valid in more than one language.
+//
+// A mnemonic valid in more than one language is not ambiguous when it encodes
+// the same entropy in all of them, since it then has the same seed too.  That
+// is the case of mnemonics made only of the characters shared by the
+// simplified and traditional Chinese lists, which are at the same indices in
+// both.  The first of those languages in Languages is returned, which is
+// ChineseSimplified for Chinese.
+func DetectLanguage(mnemonic string) (*Language, error) {
+	mnemonicSlice := strings.Fields(norm.NFKD.String(mnemonic))
+	if !validWordCount(len(mnemonicSlice)) {
+		return nil, &WordCountError{Count: len(mnemonicSlice)}
+	}
+
+	var candidates, matches []*Language
+	for _, language := range Languages {
+		if !language.containsAll(mnemonicSlice) {
+			continue
+		}
+		candidates = append(candidates, language)
+
+		if language.IsMnemonicValid(mnemonic) {
+			matches = append(matches, language)
+		}
+	}
+
+	switch {
+	case len(matches) == 1:
+		return matches[0], nil
+	case len(matches) > 1:
+		if !sameEntropy(mnemonic, matches) {
+			return nil, &AmbiguousLanguageError{Languages: matches}
+		}
+		return matches[0], nil
+	case len(candidates) == 0:
+		return nil, ErrUnknownLanguage
+	default:
+		return nil, ErrChecksumIncorrect
+	}
+}
+
+// sameEntropy returns whether or not the mnemonic encodes the same entropy in
+// every one of the languages it is valid in.
+func sameEntropy(mnemonic string, languages []*Language) bool {
+	first, err := languages[0].ParseMnemonic(mnemonic)
+	if err != nil {
+		return false
+	}
+	defer first.Zero()
+
+	for _, language := range languages[1:] {
+		m, err := language.ParseMnemonic(mnemonic)
+		if err != nil {
+			return false
+		}
+		same := bytes.Equal(first.Entropy, m.Entropy)
+		m.Zero()
+		if !same {
+			return false
+		}
+	}
+	return true
+}
+
+// containsAll returns whether or not every one of the given words is in the
+// language.
+func (l *Language) containsAll(words []string) bool {
+	for _, word := range words {
+		if _, ok := l.wordMap[word]; !ok {
+			return false
+		}
+	}
+	return true
+}
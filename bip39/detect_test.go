@@ -0,0 +1,149 @@
+package bip39
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	for _, language := range Languages {
+		for i := 0; i < 20; i++ {
+			entropy, err := NewEntropy(128)
+			assertNil(t, err)
+
+			mnemonic, err := language.NewMnemonic(entropy)
+			assertNil(t, err)
+
+			detected, err := DetectLanguage(mnemonic)
+			assertNil(t, err)
+			if language == ChineseTraditional && detected == ChineseSimplified {
+				// Mnemonics made only of characters shared by both
+				// Chinese lists are detected as simplified Chinese,
+				// which encodes the same entropy.
+				assertTrue(t, ChineseSimplified.IsMnemonicValid(mnemonic))
+				continue
+			}
+			assertEqualString(t, language.Name(), detected.Name())
+		}
+	}
+}
+
+func TestDetectLanguageErrors(t *testing.T) {
+	_, err := DetectLanguage("abandon abandon abandon")
+	assertTrue(t, errors.Is(err, ErrInvalidMnemonic))
+	countErr, ok := err.(*WordCountError)
+	assertTrue(t, ok)
+	assertEqual(t, 3, countErr.Count)
+
+	_, err = DetectLanguage("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon ábaco")
+	assertEqual(t, ErrUnknownLanguage, err)
+
+	_, err = DetectLanguage("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon yellow")
+	assertEqual(t, ErrChecksumIncorrect, err)
+}
+
+func TestDetectLanguageSharedWords(t *testing.T) {
+	var shared []string
+	for _, word := range English.WordList() {
+		if _, ok := French.WordIndex(word); ok {
+			shared = append(shared, word)
+		}
+	}
+
+	// Build mnemonics made only of words in both English and French and
+	// make sure the checksum decides between them.
+	var foundEnglish, foundFrench, foundBoth bool
+	for i := 0; i+11 < len(shared); i++ {
+		prefix := strings.Join(shared[i:i+11], " ")
+		for _, last := range shared {
+			mnemonic := prefix + " " + last
+			inEnglish := English.IsMnemonicValid(mnemonic)
+			inFrench := French.IsMnemonicValid(mnemonic)
+
+			detected, err := DetectLanguage(mnemonic)
+			switch {
+			case inEnglish && inFrench:
+				foundBoth = true
+				ambiguous, ok := err.(*AmbiguousLanguageError)
+				assertTrue(t, ok)
+				if ok {
+					assertEqual(t, 2, len(ambiguous.Languages))
+					assertTrue(t, strings.Contains(ambiguous.Error(), "english"))
+					assertTrue(t, strings.Contains(ambiguous.Error(), "french"))
+				}
+			case inEnglish:
+				foundEnglish = true
+				assertNil(t, err)
+				assertTrue(t, detected == English)
+			case inFrench:
+				foundFrench = true
+				assertNil(t, err)
+				assertTrue(t, detected == French)
+			default:
+				assertEqual(t, ErrChecksumIncorrect, err)
+			}
+		}
+	}
+
+	assertTrue(t, foundEnglish)
+	assertTrue(t, foundFrench)
+	assertTrue(t, foundBoth)
+}
+
+func TestDetectLanguageChinese(t *testing.T) {
+	var sharedIdx, simplifiedOnlyIdx int
+	for i, word := range ChineseSimplified.WordList() {
+		if _, ok := ChineseTraditional.WordIndex(word); ok {
+			sharedIdx = i
+		} else {
+			simplifiedOnlyIdx = i
+		}
+	}
+
+	// Entropy encoding the same shared character in every position
+	// produces a mnemonic valid in both lists.  The last word only holds
+	// the checksum, which puts it among the first 16 characters, all of
+	// which are shared.  Both lists decode it to the same entropy, so it
+	// isn't ambiguous and simplified Chinese is returned.
+	entropy := indicesToEntropy(sharedIdx)
+	mnemonic, err := ChineseSimplified.NewMnemonic(entropy)
+	assertNil(t, err)
+	assertTrue(t, ChineseTraditional.containsAll(strings.Fields(mnemonic)))
+	assertTrue(t, ChineseTraditional.IsMnemonicValid(mnemonic))
+	detected, err := DetectLanguage(mnemonic)
+	assertNil(t, err)
+	assertTrue(t, detected == ChineseSimplified)
+
+	// A mnemonic of shared characters found in the wild.
+	mnemonic = "初 酚 塞 敲 猛 井 甜 凸 良 交 松 作"
+	assertTrue(t, ChineseTraditional.IsMnemonicValid(mnemonic))
+	detected, err = DetectLanguage(mnemonic)
+	assertNil(t, err)
+	assertTrue(t, detected == ChineseSimplified)
+
+	// A character only found in the simplified list makes it unambiguous.
+	entropy = indicesToEntropy(simplifiedOnlyIdx)
+	mnemonic, err = ChineseSimplified.NewMnemonic(entropy)
+	assertNil(t, err)
+	detected, err = DetectLanguage(mnemonic)
+	assertNil(t, err)
+	assertTrue(t, detected == ChineseSimplified)
+}
+
+// indicesToEntropy returns 128 bits of entropy whose mnemonic uses the word at
+// the given index for its first 11 words.
+func indicesToEntropy(idx int) []byte {
+	entropy := make([]byte, 16)
+	for i := 0; i < 11; i++ {
+		for bit := 0; bit < 11; bit++ {
+			if idx&(1<<uint(10-bit)) != 0 {
+				pos := i*11 + bit
+				entropy[pos/8] |= 1 << uint(7-pos%8)
+			}
+		}
+	}
+	// The final 7 bits of entropy are left unset, so the last word only
+	// depends on the checksum.
+	return entropy
+}
@@ -0,0 +1,132 @@
+package bip39
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math"
+
+	"github.com/grupokindynos/ogen-utils/secret"
+)
+
+// biasCriticalZ is the standard normal quantile for the 0.001 significance
+// level used when checking rolls for bias.
+const biasCriticalZ = 3.090232
+
+var (
+	// ErrInvalidDie is returned when a die has fewer than 2 or more than 256
+	// sides.
+	ErrInvalidDie = errors.New("a die must have between 2 and 256 sides")
+
+	// ErrInvalidRoll is returned when a roll is outside the range of the
+	// die.
+	ErrInvalidRoll = errors.New("roll out of range for the die")
+
+	// ErrNotEnoughRolls is returned when the rolls don't hold enough entropy
+	// for the requested entropy size.
+	ErrNotEnoughRolls = errors.New("not enough rolls for the requested entropy size")
+
+	// ErrBiasedRolls is returned when the rolls are too unevenly distributed
+	// to have come from a fair die or coin.
+	ErrBiasedRolls = errors.New("rolls appear to be biased")
+)
+
+// MinDiceRolls returns the number of rolls of a die with the given number of
+// sides needed to gather bitSize bits of entropy.
+func MinDiceRolls(sides, bitSize int) int {
+	return int(math.Ceil(float64(bitSize) / math.Log2(float64(sides))))
+}
+
+// EntropyFromDiceRolls returns bitSize bits of entropy gathered from rolls of
+// a fair die with the given number of sides, numbered from 1.  The result may
+// be passed to NewMnemonic.
+//
+// At least MinDiceRolls rolls are required.  The rolls are checked with a
+// chi-squared test and ErrBiasedRolls is returned when they are unlikely to
+// come from a fair die.  The entropy is the first bitSize bits of the SHA256
+// of the rolls, each encoded as a single byte holding the roll minus one, so
+// extra rolls only add entropy.
+func EntropyFromDiceRolls(rolls []int, sides, bitSize int) ([]byte, error) {
+	if err := validateEntropyBitSize(bitSize); err != nil {
+		return nil, err
+	}
+	if sides < 2 || sides > 256 {
+		return nil, ErrInvalidDie
+	}
+	if len(rolls) < MinDiceRolls(sides, bitSize) {
+		return nil, ErrNotEnoughRolls
+	}
+
+	encoded := make([]byte, len(rolls))
+	defer secret.Zero(encoded)
+	for i, roll := range rolls {
+		if roll < 1 || roll > sides {
+			return nil, ErrInvalidRoll
+		}
+		encoded[i] = byte(roll - 1)
+	}
+
+	if isBiased(encoded, sides) {
+		return nil, ErrBiasedRolls
+	}
+
+	hash := sha256.Sum256(encoded)
+	defer secret.Zero(hash[:])
+
+	entropy := make([]byte, bitSize/8)
+	copy(entropy, hash[:])
+	return entropy, nil
+}
+
+// EntropyFromCoinFlips returns entropy made of the given coin flips, one bit
+// per flip with heads being a one bit.  Exactly bitSize flips are required
+// and the result may be passed to NewMnemonic.
+//
+// The flips are checked with a chi-squared test and ErrBiasedRolls is
+// returned when they are unlikely to come from a fair coin.
+func EntropyFromCoinFlips(flips []bool, bitSize int) ([]byte, error) {
+	if err := validateEntropyBitSize(bitSize); err != nil {
+		return nil, err
+	}
+	if len(flips) != bitSize {
+		return nil, ErrNotEnoughRolls
+	}
+
+	encoded := make([]byte, len(flips))
+	defer secret.Zero(encoded)
+	entropy := make([]byte, bitSize/8)
+	for i, heads := range flips {
+		if heads {
+			encoded[i] = 1
+			entropy[i/8] |= 1 << uint(7-i%8)
+		}
+	}
+
+	if isBiased(encoded, 2) {
+		secret.Zero(entropy)
+		return nil, ErrBiasedRolls
+	}
+	return entropy, nil
+}
+
+// isBiased returns whether or not a chi-squared goodness of fit test rejects
+// the hypothesis that the rolls, ranging from 0 to sides - 1, come from a
+// fair die at the 0.001 significance level.
+func isBiased(rolls []byte, sides int) bool {
+	counts := make([]int, sides)
+	for _, roll := range rolls {
+		counts[roll]++
+	}
+
+	expected := float64(len(rolls)) / float64(sides)
+	var chiSquared float64
+	for _, count := range counts {
+		diff := float64(count) - expected
+		chiSquared += diff * diff / expected
+	}
+
+	// Approximate the critical value of the chi-squared distribution
+	// using the Wilson-Hilferty transformation.
+	df := float64(sides - 1)
+	critical := df * math.Pow(1-2/(9*df)+biasCriticalZ*math.Sqrt(2/(9*df)), 3)
+	return chiSquared > critical
+}
@@ -0,0 +1,96 @@
+package bip39
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMinDiceRolls(t *testing.T) {
+	assertEqual(t, 50, MinDiceRolls(6, 128))
+	assertEqual(t, 100, MinDiceRolls(6, 256))
+	assertEqual(t, 128, MinDiceRolls(2, 128))
+	assertEqual(t, 32, MinDiceRolls(16, 128))
+}
+
+func TestEntropyFromDiceRolls(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	rolls := make([]int, MinDiceRolls(6, 256))
+	for i := range rolls {
+		rolls[i] = r.Intn(6) + 1
+	}
+
+	entropy, err := EntropyFromDiceRolls(rolls, 6, 256)
+	assertNil(t, err)
+	assertEqual(t, 32, len(entropy))
+
+	// The same rolls always produce the same entropy and a valid
+	// mnemonic.
+	again, err := EntropyFromDiceRolls(rolls, 6, 256)
+	assertNil(t, err)
+	assertEqualByteSlices(t, entropy, again)
+
+	mnemonic, err := NewMnemonic(entropy)
+	assertNil(t, err)
+	assertTrue(t, IsMnemonicValid(mnemonic))
+
+	short, err := EntropyFromDiceRolls(rolls, 6, 128)
+	assertNil(t, err)
+	assertEqual(t, 16, len(short))
+}
+
+func TestEntropyFromDiceRollsErrors(t *testing.T) {
+	rolls := make([]int, 100)
+	for i := range rolls {
+		rolls[i] = i%6 + 1
+	}
+
+	_, err := EntropyFromDiceRolls(rolls[:49], 6, 128)
+	assertEqual(t, ErrNotEnoughRolls, err)
+
+	_, err = EntropyFromDiceRolls(rolls, 1, 128)
+	assertEqual(t, ErrInvalidDie, err)
+
+	_, err = EntropyFromDiceRolls(rolls, 6, 100)
+	assertEqual(t, ErrEntropyLengthInvalid, err)
+
+	rolls[10] = 7
+	_, err = EntropyFromDiceRolls(rolls, 6, 128)
+	assertEqual(t, ErrInvalidRoll, err)
+
+	// A die which mostly lands on six.
+	for i := range rolls {
+		rolls[i] = 6
+		if i%10 == 0 {
+			rolls[i] = i%5 + 1
+		}
+	}
+	_, err = EntropyFromDiceRolls(rolls, 6, 128)
+	assertEqual(t, ErrBiasedRolls, err)
+}
+
+func TestEntropyFromCoinFlips(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	flips := make([]bool, 128)
+	for i := range flips {
+		flips[i] = r.Intn(2) == 1
+	}
+
+	entropy, err := EntropyFromCoinFlips(flips, 128)
+	assertNil(t, err)
+	assertEqual(t, 16, len(entropy))
+
+	// Each flip is one bit of entropy.
+	for i, heads := range flips {
+		assertEqual(t, heads, entropy[i/8]&(1<<uint(7-i%8)) != 0)
+	}
+
+	_, err = EntropyFromCoinFlips(flips[:127], 128)
+	assertEqual(t, ErrNotEnoughRolls, err)
+
+	allHeads := make([]bool, 128)
+	for i := range allHeads {
+		allHeads[i] = true
+	}
+	_, err = EntropyFromCoinFlips(allHeads, 128)
+	assertEqual(t, ErrBiasedRolls, err)
+}
@@ -0,0 +1,263 @@
+package bip39
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/grupokindynos/ogen-utils/secret"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ElectrumSeedType is the version prefix of an Electrum seed phrase, which is
+// the hex prefix of HMAC-SHA512("Seed version", phrase).
+type ElectrumSeedType string
+
+const (
+	// ElectrumStandard is the version of standard wallet seeds.
+	ElectrumStandard ElectrumSeedType = "01"
+
+	// ElectrumSegwit is the version of segwit wallet seeds.
+	ElectrumSegwit ElectrumSeedType = "100"
+
+	// Electrum2FA is the version of two-factor wallet seeds.
+	Electrum2FA ElectrumSeedType = "101"
+
+	// Electrum2FASegwit is the version of two-factor segwit wallet seeds.
+	Electrum2FASegwit ElectrumSeedType = "102"
+)
+
+// electrumSeedTypes are the known seed versions.
+var electrumSeedTypes = []ElectrumSeedType{
+	ElectrumStandard,
+	ElectrumSegwit,
+	Electrum2FA,
+	Electrum2FASegwit,
+}
+
+var (
+	// ErrNotElectrumSeed is returned when a phrase doesn't carry a known
+	// Electrum seed version.
+	ErrNotElectrumSeed = errors.New("not an Electrum versioned seed")
+
+	// ErrUnknownElectrumSeedType is returned when generating a phrase for an
+	// unknown seed version.
+	ErrUnknownElectrumSeedType = errors.New("unknown Electrum seed type")
+)
+
+const (
+	// electrumEntropyBits is the size of the entropy of a new phrase,
+	// which makes it 12 words long.
+	electrumEntropyBits = 132
+)
+
+// electrumVersionKey is the HMAC key used to compute the seed version.
+var electrumVersionKey = []byte("Seed version")
+
+// cjkRanges are the Unicode ranges in which whitespace between characters is
+// removed by Electrum when normalizing a phrase.
+var cjkRanges = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x11ff, Stride: 1}, // Hangul Jamo
+		{Lo: 0x2e80, Hi: 0x2eff, Stride: 1}, // CJK Radicals Supplement
+		{Lo: 0x2f00, Hi: 0x2fdf, Stride: 1}, // CJK Radicals
+		{Lo: 0x2ff0, Hi: 0x2fff, Stride: 1}, // Ideographic Description Characters
+		{Lo: 0x3040, Hi: 0x309f, Stride: 1}, // Hiragana
+		{Lo: 0x30a0, Hi: 0x30ff, Stride: 1}, // Katakana
+		{Lo: 0x3100, Hi: 0x312f, Stride: 1}, // Bopomofo
+		{Lo: 0x3130, Hi: 0x318f, Stride: 1}, // Hangul Compatibility Jamo
+		{Lo: 0x3190, Hi: 0x319f, Stride: 1}, // Kanbun
+		{Lo: 0x31a0, Hi: 0x31bf, Stride: 1}, // Bopomofo Extended
+		{Lo: 0x31c0, Hi: 0x31ef, Stride: 1}, // CJK Strokes
+		{Lo: 0x31f0, Hi: 0x31ff, Stride: 1}, // Katakana Phonetic Extensions
+		{Lo: 0x3400, Hi: 0x4dbf, Stride: 1}, // CJK Unified Ideographs Extension A
+		{Lo: 0x4e00, Hi: 0x9fff, Stride: 1}, // CJK Unified Ideographs
+		{Lo: 0xa000, Hi: 0xa48f, Stride: 1}, // Yi Syllables
+		{Lo: 0xa490, Hi: 0xa4cf, Stride: 1}, // Yi Radicals
+		{Lo: 0xa4d0, Hi: 0xa4ff, Stride: 1}, // Lisu
+		{Lo: 0xa960, Hi: 0xa97f, Stride: 1}, // Hangul Jamo Extended A
+		{Lo: 0xac00, Hi: 0xd7af, Stride: 1}, // Hangul Syllables
+		{Lo: 0xd7b0, Hi: 0xd7ff, Stride: 1}, // Hangul Jamo Extended B
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1}, // CJK Compatibility Ideographs
+		{Lo: 0xff00, Hi: 0xffef, Stride: 1}, // Halfwidth and Fullwidth Forms
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16f00, Hi: 0x16f9f, Stride: 1}, // Miao
+		{Lo: 0x1b000, Hi: 0x1b0ff, Stride: 1}, // Kana Supplement
+		{Lo: 0x20000, Hi: 0x2a6df, Stride: 1}, // CJK Unified Ideographs Extension B
+		{Lo: 0x2a700, Hi: 0x2b73f, Stride: 1}, // CJK Unified Ideographs Extension C
+		{Lo: 0x2b740, Hi: 0x2b81f, Stride: 1}, // CJK Unified Ideographs Extension D
+		{Lo: 0x2f800, Hi: 0x2fa1d, Stride: 1}, // CJK Compatibility Ideographs Supplement
+		{Lo: 0xe0100, Hi: 0xe01ef, Stride: 1}, // Variation Selectors Supplement
+	},
+}
+
+// ElectrumSeed is the seed derived from an Electrum versioned phrase.  It is a
+// distinct type so it can't be mistaken for the output of NewSeed, since both
+// the phrase and the seed derivation differ from BIP-39.
+type ElectrumSeed struct {
+	seedType ElectrumSeedType
+	seed     []byte
+}
+
+// Type returns the version of the phrase the seed was derived from.
+func (s *ElectrumSeed) Type() ElectrumSeedType {
+	return s.seedType
+}
+
+// Bytes returns the 64 byte seed.  The returned slice is cleared by Zero.
+func (s *ElectrumSeed) Bytes() []byte {
+	return s.seed
+}
+
+// Zero clears the seed.
+func (s *ElectrumSeed) Zero() {
+	secret.Zero(s.seed)
+}
+
+// normalizeElectrum normalizes a phrase or passphrase the way Electrum does:
+// Unicode NFKD form, lower case, without combining marks, words separated by
+// a single space and no spaces between CJK characters.
+func normalizeElectrum(text string) string {
+	text = strings.ToLower(norm.NFKD.String(text))
+
+	var b strings.Builder
+	for _, r := range text {
+		if norm.NFKD.PropertiesString(string(r)).CCC() != 0 {
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	runes := []rune(strings.Join(strings.Fields(b.String()), " "))
+	b.Reset()
+	for i, r := range runes {
+		if r == ' ' && unicode.Is(cjkRanges, runes[i-1]) && unicode.Is(cjkRanges, runes[i+1]) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// electrumVersion returns the hex encoded HMAC-SHA512 of a normalized phrase.
+func electrumVersion(normalized string) string {
+	mac := hmac.New(sha512.New, electrumVersionKey)
+	mac.Write([]byte(normalized))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ElectrumSeedVersion returns the Electrum seed version of a phrase.  The
+// second return value is false when the phrase is not a versioned seed.
+//
+// Any phrase whose version matches is accepted, regardless of its words, as
+// Electrum does.  Old style Electrum seeds, which predate versioning, are not
+// detected.
+func ElectrumSeedVersion(mnemonic string) (ElectrumSeedType, bool) {
+	version := electrumVersion(normalizeElectrum(mnemonic))
+	for _, seedType := range electrumSeedTypes {
+		if strings.HasPrefix(version, string(seedType)) {
+			return seedType, true
+		}
+	}
+	return "", false
+}
+
+// IsElectrumSeed returns whether or not the phrase is an Electrum versioned
+// seed.
+func IsElectrumSeed(mnemonic string) bool {
+	_, ok := ElectrumSeedVersion(mnemonic)
+	return ok
+}
+
+// NewElectrumSeed derives the seed of an Electrum versioned phrase, which is
+// PBKDF2-HMAC-SHA512 of the normalized phrase with the salt "electrum"
+// followed by the normalized passphrase.  ErrNotElectrumSeed is returned when
+// the phrase is not versioned.
+func NewElectrumSeed(mnemonic, passphrase string) (*ElectrumSeed, error) {
+	normalized := normalizeElectrum(mnemonic)
+	seedType, ok := ElectrumSeedVersion(normalized)
+	if !ok {
+		return nil, ErrNotElectrumSeed
+	}
+
+	password := []byte(normalized)
+	defer secret.Zero(password)
+	salt := append([]byte("electrum"), normalizeElectrum(passphrase)...)
+	defer secret.Zero(salt)
+
+	return &ElectrumSeed{
+		seedType: seedType,
+		seed:     pbkdf2.Key(password, salt, 2048, 64, sha512.New),
+	}, nil
+}
+
+// NewElectrumMnemonic returns a new 12 word Electrum phrase of the given
+// version using the list of words set by SetWordList.
+func NewElectrumMnemonic(seedType ElectrumSeedType) (string, error) {
+	return defaultLanguage.NewElectrumMnemonic(seedType)
+}
+
+// NewElectrumMnemonic returns a new 12 word Electrum phrase of the given
+// version in this language.
+//
+// Like Electrum, random entropy is incremented until its encoding has the
+// requested version.  Phrases which are also valid BIP-39 mnemonics are
+// skipped so the two can't be confused.
+func (l *Language) NewElectrumMnemonic(seedType ElectrumSeedType) (string, error) {
+	known := false
+	for _, t := range electrumSeedTypes {
+		known = known || t == seedType
+	}
+	if !known {
+		return "", ErrUnknownElectrumSeedType
+	}
+
+	// The entropy must be at least 2^121 for the phrase to have 12 words.
+	max := new(big.Int).Lsh(bigOne, electrumEntropyBits)
+	min := new(big.Int).Lsh(bigOne, electrumEntropyBits-11)
+	entropy := new(big.Int)
+	defer zeroBigInt(entropy)
+	for entropy.Cmp(min) < 0 {
+		r, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		entropy.Set(r)
+		zeroBigInt(r)
+	}
+
+	for {
+		entropy.Add(entropy, bigOne)
+		mnemonic := l.electrumEncode(entropy)
+		if l.IsMnemonicValid(mnemonic) {
+			continue
+		}
+		if strings.HasPrefix(electrumVersion(normalizeElectrum(mnemonic)), string(seedType)) {
+			return mnemonic, nil
+		}
+	}
+}
+
+// electrumEncode encodes a number as words the way Electrum does, least
+// significant word first.
+func (l *Language) electrumEncode(i *big.Int) string {
+	n := big.NewInt(int64(len(l.wordList)))
+	v := new(big.Int).Set(i)
+	defer zeroBigInt(v)
+	x := new(big.Int)
+	defer zeroBigInt(x)
+
+	var words []string
+	for v.Sign() > 0 {
+		v.DivMod(v, n, x)
+		words = append(words, l.wordList[x.Int64()])
+	}
+	return strings.Join(words, l.separator)
+}
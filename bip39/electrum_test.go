@@ -0,0 +1,91 @@
+package bip39
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestElectrumSeed(t *testing.T) {
+	tests := []struct {
+		mnemonic   string
+		passphrase string
+		seedType   ElectrumSeedType
+		seed       string
+	}{
+		{
+			mnemonic: "wild father tree among universe such mobile favorite target dynamic credit identify",
+			seedType: ElectrumSegwit,
+			seed:     "aac2a6302e48577ab4b46f23dbae0774e2e62c796f797d0a1b5faeb528301e3064342dafb79069e7c4c6b8c38ae11d7a973bec0d4f70626f8cc5184a8d0b0756",
+		},
+		{
+			mnemonic:   "wild father tree among universe such mobile favorite target dynamic credit identify",
+			passphrase: "Did you ever hear the tragedy of Darth Plagueis the Wise?",
+			seedType:   ElectrumSegwit,
+			seed:       "4aa29f2aeb0127efb55138ab9e7be83b36750358751906f86c662b21a1ea1370f949e6d1a12fa56d3d93cadda93038c76ac8118597364e46f5156fde6183c82f",
+		},
+		{
+			mnemonic: "almíbar tibio superar vencer hacha peatón príncipe matar consejo polen vehículo odisea",
+			seedType: ElectrumStandard,
+			seed:     "18bffd573a960cc775bbd80ed60b7dc00bc8796a186edebe7fc7cf1f316da0fe937852a969c5c79ded8255cdf54409537a16339fbe33fb9161af793ea47faa7a",
+		},
+		{
+			mnemonic: "なのか ひろい しなん まなぶ つぶす さがす おしゃれ かわく おいかける けさき かいとう さたん",
+			seedType: ElectrumStandard,
+			seed:     "d3eaf0e44ddae3a5769cb08a26918e8b308258bcb057bb704c6f69713245c0b35cb92c03df9c9ece5eff826091b4e74041e010b701d44d610976ce8bfb66a8ad",
+		},
+	}
+
+	for _, test := range tests {
+		seedType, ok := ElectrumSeedVersion(test.mnemonic)
+		assertTrue(t, ok)
+		assertEqual(t, test.seedType, seedType)
+		assertTrue(t, IsElectrumSeed(test.mnemonic))
+
+		seed, err := NewElectrumSeed(test.mnemonic, test.passphrase)
+		assertNil(t, err)
+		assertEqual(t, test.seedType, seed.Type())
+		assertEqualString(t, test.seed, hex.EncodeToString(seed.Bytes()))
+
+		// The seed doesn't depend on case, accents or extra whitespace.
+		seed, err = NewElectrumSeed("  "+strings.ToUpper(test.mnemonic)+" ", test.passphrase)
+		assertNil(t, err)
+		assertEqualString(t, test.seed, hex.EncodeToString(seed.Bytes()))
+
+		seed.Zero()
+		assertEqualString(t, strings.Repeat("00", 64), hex.EncodeToString(seed.Bytes()))
+	}
+}
+
+func TestElectrumSeedNotVersioned(t *testing.T) {
+	for _, vector := range testVectors() {
+		assertFalse(t, IsElectrumSeed(vector.mnemonic))
+
+		_, err := NewElectrumSeed(vector.mnemonic, "TREZOR")
+		assertEqual(t, ErrNotElectrumSeed, err)
+	}
+}
+
+func TestNewElectrumMnemonic(t *testing.T) {
+	for _, language := range []*Language{English, Japanese} {
+		for _, seedType := range electrumSeedTypes {
+			mnemonic, err := language.NewElectrumMnemonic(seedType)
+			assertNil(t, err)
+			assertEqual(t, 12, len(strings.Fields(mnemonic)))
+			assertFalse(t, language.IsMnemonicValid(mnemonic))
+
+			actualType, ok := ElectrumSeedVersion(mnemonic)
+			assertTrue(t, ok)
+			assertEqual(t, seedType, actualType)
+		}
+	}
+
+	_, err := NewElectrumMnemonic(ElectrumSeedType("2"))
+	assertEqual(t, ErrUnknownElectrumSeedType, err)
+}
+
+func TestNormalizeElectrum(t *testing.T) {
+	assertEqualString(t, "almibar tibio", normalizeElectrum(" Almíbar \t TIBIO\n"))
+	assertEqualString(t, "なのかひろい", normalizeElectrum("なのか　ひろい"))
+	assertEqualString(t, "abc なのか", normalizeElectrum("abc なのか"))
+}
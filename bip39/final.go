@@ -0,0 +1,64 @@
+package bip39
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/grupokindynos/ogen-utils/secret"
+	"golang.org/x/text/unicode/norm"
+)
+
+// FinalWords returns every word which completes the given mnemonic, missing
+// its last word, into a valid mnemonic.
+//
+// The last word of a mnemonic holds the final bits of entropy followed by
+// the checksum, so there are 2^(11 - checksum bits) valid final words: 128
+// for a 12 word mnemonic down to 8 for a 24 word mnemonic.  This allows
+// choosing the first words by hand, for instance from dice rolls, and then
+// picking one of the returned words.  The words are returned in wordlist
+// order.
+//
+// A *WordCountError with the number of words of the completed mnemonic is
+// returned when it would not have a supported number of words, and an
+// *UnknownWordError for the first word not in the wordlist.
+func (l *Language) FinalWords(mnemonic string) ([]string, error) {
+	mnemonicSlice := strings.Fields(norm.NFKD.String(mnemonic))
+	wordCount := len(mnemonicSlice) + 1
+	if !validWordCount(wordCount) {
+		return nil, &WordCountError{Count: wordCount}
+	}
+
+	// Pack the 11 bits of every word into a big.Int.
+	partialEntropy := big.NewInt(0)
+	defer zeroBigInt(partialEntropy)
+	for i, word := range mnemonicSlice {
+		idx, ok := l.wordMap[word]
+		if !ok {
+			return nil, &UnknownWordError{Position: i, Word: word}
+		}
+		partialEntropy.Lsh(partialEntropy, 11)
+		partialEntropy.Or(partialEntropy, big.NewInt(int64(idx)))
+	}
+
+	checksumBitSize := uint(wordCount * 11 / 33)
+	freeBitSize := 11 - checksumBitSize
+	entropyByteSize := (wordCount*11 - int(checksumBitSize)) / 8
+
+	entropy := new(big.Int)
+	defer zeroBigInt(entropy)
+
+	finalWords := make([]string, 0, 1<<freeBitSize)
+	for v := int64(0); v < 1<<freeBitSize; v++ {
+		// The free bits of the final word complete the entropy.
+		entropy.Lsh(partialEntropy, freeBitSize)
+		entropy.Or(entropy, big.NewInt(v))
+
+		entropyBytes := padByteSlice(entropy.Bytes(), entropyByteSize)
+		checksum := computeChecksum(entropyBytes)[0] >> (8 - checksumBitSize)
+		secret.Zero(entropyBytes)
+
+		idx := int(v)<<checksumBitSize | int(checksum)
+		finalWords = append(finalWords, l.wordList[idx])
+	}
+	return finalWords, nil
+}
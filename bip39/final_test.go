@@ -0,0 +1,44 @@
+package bip39
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFinalWords(t *testing.T) {
+	expectedCounts := map[int]int{12: 128, 15: 64, 18: 32, 21: 16, 24: 8}
+
+	for _, vector := range testVectors() {
+		words := strings.Fields(vector.mnemonic)
+		partial := strings.Join(words[:len(words)-1], " ")
+
+		finalWords, err := English.FinalWords(partial)
+		assertNil(t, err)
+		assertEqual(t, expectedCounts[len(words)], len(finalWords))
+
+		found := false
+		for _, word := range finalWords {
+			if word == words[len(words)-1] {
+				found = true
+			}
+			assertTrue(t, IsMnemonicValid(partial+" "+word))
+		}
+		assertTrue(t, found)
+	}
+}
+
+func TestFinalWordsErrors(t *testing.T) {
+	_, err := English.FinalWords("abandon abandon abandon")
+	assertTrue(t, errors.Is(err, ErrInvalidMnemonic))
+	countErr, ok := err.(*WordCountError)
+	assertTrue(t, ok)
+	assertEqual(t, 4, countErr.Count)
+
+	_, err = English.FinalWords("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandn")
+	assertTrue(t, errors.Is(err, ErrUnknownWord))
+	wordErr, ok := err.(*UnknownWordError)
+	assertTrue(t, ok)
+	assertEqual(t, 10, wordErr.Position)
+	assertEqualString(t, "abandn", wordErr.Word)
+}
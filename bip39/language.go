@@ -0,0 +1,143 @@
+package bip39
+
+import (
+	"sync"
+
+	"github.com/grupokindynos/ogen-utils/bip39/words"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// wordSeparator separates the words of a mnemonic in most languages.
+	wordSeparator = " "
+
+	// ideographicSpace separates the words of a Japanese mnemonic.
+	ideographicSpace = "\u3000"
+)
+
+// Language is a list of words used to encode mnemonics along with a reverse
+// lookup index for it.  Unlike SetWordList, a Language doesn't depend on any
+// package-level state, so values for different languages may be used
+// concurrently.
+type Language struct {
+	// name identifies the language in error messages
+	name string
+
+	// separator is placed between the words of new mnemonics
+	separator string
+
+	// wordList is the set of words to use
+	wordList []string
+
+	// wordMap is a reverse lookup map for wordList keyed by the Unicode NFKD
+	// form of each word
+	wordMap map[string]int
+
+	// sorted and sortedIdx are the words in Unicode NFC form sorted for
+	// prefix searches along with their indices in wordList.  They are
+	// computed on first use.
+	prefixOnce sync.Once
+	sorted     []string
+	sortedIdx  []int
+}
+
+var (
+	// English is the English BIP-39 wordlist.
+	English = newLanguage("english", wordSeparator, words.English)
+
+	// Japanese is the Japanese BIP-39 wordlist.
+	Japanese = newLanguage("japanese", ideographicSpace, words.Japanese)
+
+	// Korean is the Korean BIP-39 wordlist.
+	Korean = newLanguage("korean", wordSeparator, words.Korean)
+
+	// Spanish is the Spanish BIP-39 wordlist.
+	Spanish = newLanguage("spanish", wordSeparator, words.Spanish)
+
+	// ChineseSimplified is the simplified Chinese BIP-39 wordlist.
+	ChineseSimplified = newLanguage("chinese_simplified", wordSeparator, words.ChineseSimplified)
+
+	// ChineseTraditional is the traditional Chinese BIP-39 wordlist.
+	ChineseTraditional = newLanguage("chinese_traditional", wordSeparator, words.ChineseTraditional)
+
+	// French is the French BIP-39 wordlist.
+	French = newLanguage("french", wordSeparator, words.French)
+
+	// Italian is the Italian BIP-39 wordlist.
+	Italian = newLanguage("italian", wordSeparator, words.Italian)
+
+	// Czech is the Czech BIP-39 wordlist.
+	Czech = newLanguage("czech", wordSeparator, words.Czech)
+
+	// Languages are all of the predefined languages.
+	Languages = []*Language{
+		English,
+		Japanese,
+		Korean,
+		Spanish,
+		ChineseSimplified,
+		ChineseTraditional,
+		French,
+		Italian,
+		Czech,
+	}
+)
+
+// NewLanguage returns a new language using the given list of words.  The list
+// should contain 2048 unique words and must not be modified afterwards.
+//
+// When the list is the list of one of the predefined languages, that language
+// is returned so the appropriate word separator is used.  Otherwise words of
+// new mnemonics are separated by an ASCII space.
+func NewLanguage(list []string) *Language {
+	for _, language := range Languages {
+		if sameList(language.wordList, list) {
+			return language
+		}
+	}
+	return newLanguage("", wordSeparator, list)
+}
+
+// newLanguage returns a new language with the given name and word separator
+// using the given list of words.
+func newLanguage(name, separator string, list []string) *Language {
+	wordMap := make(map[string]int, len(list))
+	for i, v := range list {
+		wordMap[norm.NFKD.String(v)] = i
+	}
+	return &Language{
+		name:      name,
+		separator: separator,
+		wordList:  list,
+		wordMap:   wordMap,
+	}
+}
+
+// sameList returns whether or not both slices refer to the same list of words.
+func sameList(a, b []string) bool {
+	return len(a) == len(b) && len(a) > 0 && &a[0] == &b[0]
+}
+
+// Name returns the name of the language, such as "english" or
+// "chinese_simplified".  Languages created by NewLanguage have no name.
+func (l *Language) Name() string {
+	return l.name
+}
+
+// Separator returns the separator placed between the words of new mnemonics.
+// This is the ideographic space (U+3000) for Japanese and an ASCII space for
+// every other language.
+func (l *Language) Separator() string {
+	return l.separator
+}
+
+// WordList gets the list of words of the language.
+func (l *Language) WordList() []string {
+	return l.wordList
+}
+
+// WordIndex gets the index of a word in the list of words of the language.
+func (l *Language) WordIndex(word string) (int, bool) {
+	idx, ok := l.wordMap[norm.NFKD.String(word)]
+	return idx, ok
+}
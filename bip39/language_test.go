@@ -0,0 +1,252 @@
+package bip39
+
+import (
+	"encoding/hex"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/grupokindynos/ogen-utils/bip39/words"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestLanguageWordIndex(t *testing.T) {
+	for expectedIdx, word := range words.Spanish {
+		actualIdx, ok := Spanish.WordIndex(word)
+		assertTrue(t, ok)
+		assertEqual(t, actualIdx, expectedIdx)
+	}
+
+	_, ok := Spanish.WordIndex("abandon")
+	assertFalse(t, ok)
+
+	assertEqualStringSlices(t, words.Italian, Italian.WordList())
+}
+
+func TestLanguageEnglishMatchesPackageFunctions(t *testing.T) {
+	for _, vector := range testVectors() {
+		entropy, err := hex.DecodeString(vector.entropy)
+		assertNil(t, err)
+
+		mnemonic, err := English.NewMnemonic(entropy)
+		assertNil(t, err)
+		assertEqualString(t, vector.mnemonic, mnemonic)
+		assertTrue(t, English.IsMnemonicValid(mnemonic))
+
+		actualEntropy, err := English.EntropyFromMnemonic(mnemonic)
+		assertNil(t, err)
+		assertEqualByteSlices(t, entropy, actualEntropy)
+
+		raw, err := English.MnemonicToByteArray(mnemonic, true)
+		assertNil(t, err)
+		assertEqualByteSlices(t, entropy, raw)
+
+		seed, err := English.NewSeedWithErrorChecking(mnemonic, "TREZOR")
+		assertNil(t, err)
+		assertEqualString(t, vector.seed, hex.EncodeToString(seed))
+	}
+
+	for _, vector := range badMnemonicSentences() {
+		assertFalse(t, English.IsMnemonicValid(vector.mnemonic))
+	}
+}
+
+func TestLanguageRoundTrip(t *testing.T) {
+	languages := []*Language{
+		English, Japanese, Korean, Spanish, ChineseSimplified,
+		ChineseTraditional, French, Italian, Czech,
+	}
+
+	for _, language := range languages {
+		entropy, err := NewEntropy(256)
+		assertNil(t, err)
+
+		mnemonic, err := language.NewMnemonic(entropy)
+		assertNil(t, err)
+
+		for _, word := range strings.Fields(mnemonic) {
+			_, ok := language.WordIndex(word)
+			assertTrue(t, ok)
+		}
+
+		actualEntropy, err := language.EntropyFromMnemonic(mnemonic)
+		assertNil(t, err)
+		assertEqualByteSlices(t, entropy, actualEntropy)
+	}
+}
+
+func TestLanguageIndependentOfSetWordList(t *testing.T) {
+	entropy, err := hex.DecodeString(testVectors()[0].entropy)
+	assertNil(t, err)
+
+	SetWordList(words.Spanish)
+	defer SetWordList(words.English)
+
+	spanish, err := NewMnemonic(entropy)
+	assertNil(t, err)
+	assertTrue(t, Spanish.IsMnemonicValid(spanish))
+
+	english, err := English.NewMnemonic(entropy)
+	assertNil(t, err)
+	assertEqualString(t, testVectors()[0].mnemonic, english)
+}
+
+func TestLanguageConcurrentUse(t *testing.T) {
+	var wg sync.WaitGroup
+	for _, language := range []*Language{English, Spanish} {
+		wg.Add(1)
+		go func(language *Language) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				entropy, err := NewEntropy(128)
+				assertNil(t, err)
+
+				mnemonic, err := language.NewMnemonic(entropy)
+				assertNil(t, err)
+				assertTrue(t, language.IsMnemonicValid(mnemonic))
+			}
+		}(language)
+	}
+	wg.Wait()
+}
+
+func TestJapaneseVectors(t *testing.T) {
+	for _, vector := range testVectorsJapanese() {
+		entropy, err := hex.DecodeString(vector.entropy)
+		assertNil(t, err)
+
+		mnemonic, err := Japanese.NewMnemonic(entropy)
+		assertNil(t, err)
+		assertEqualString(t, vector.mnemonic, mnemonic)
+
+		seed, err := Japanese.NewSeedWithErrorChecking(mnemonic, japanesePassphrase)
+		assertNil(t, err)
+		assertEqualString(t, vector.seed, hex.EncodeToString(seed))
+
+		// Words separated by ASCII spaces must be accepted and
+		// produce the same seed since NFKD maps U+3000 to a space.
+		asciiMnemonic := strings.Replace(mnemonic, "　", " ", -1)
+		actualEntropy, err := Japanese.EntropyFromMnemonic(asciiMnemonic)
+		assertNil(t, err)
+		assertEqualByteSlices(t, entropy, actualEntropy)
+		assertEqualString(t, vector.seed, hex.EncodeToString(NewSeed(asciiMnemonic, japanesePassphrase)))
+	}
+}
+
+func TestMnemonicWhitespace(t *testing.T) {
+	vector := testVectors()[1]
+	entropy, err := hex.DecodeString(vector.entropy)
+	assertNil(t, err)
+
+	mnemonic := "  " + strings.Replace(vector.mnemonic, " ", " \t\n ", -1) + "\n"
+	assertTrue(t, IsMnemonicValid(mnemonic))
+
+	raw, err := MnemonicToByteArray(mnemonic, true)
+	assertNil(t, err)
+	assertEqualByteSlices(t, entropy, raw)
+}
+
+func TestMnemonicNormalization(t *testing.T) {
+	entropy, err := NewEntropy(256)
+	assertNil(t, err)
+
+	for _, language := range []*Language{Spanish, French, Korean, Japanese} {
+		mnemonic, err := language.NewMnemonic(entropy)
+		assertNil(t, err)
+
+		// The same mnemonic in decomposed form must be accepted.
+		decomposed := norm.NFKD.String(mnemonic)
+		actualEntropy, err := language.EntropyFromMnemonic(decomposed)
+		assertNil(t, err)
+		assertEqualByteSlices(t, entropy, actualEntropy)
+
+		_, err = language.MnemonicToByteArray(decomposed)
+		assertNil(t, err)
+
+		assertEqualByteSlices(t, NewSeed(mnemonic, "TREZOR"), NewSeed(decomposed, "TREZOR"))
+	}
+
+	// Passwords are normalized as well.
+	assertEqualByteSlices(t, NewSeed(testVectors()[0].mnemonic, "caf\u00e9"),
+		NewSeed(testVectors()[0].mnemonic, "cafe\u0301"))
+}
+
+func TestLanguageSeparator(t *testing.T) {
+	assertEqualString(t, "　", Japanese.Separator())
+	assertEqualString(t, " ", English.Separator())
+	assertTrue(t, NewLanguage(words.Japanese) == Japanese)
+	assertEqualString(t, " ", NewLanguage(append([]string{}, words.Japanese...)).Separator())
+}
+
+// japanesePassphrase is the passphrase used by the Japanese test vectors.
+const japanesePassphrase = "㍍ガバヴァぱばぐゞちぢ十人十色"
+
+// testVectorsJapanese returns test vectors from
+// https://github.com/bip32JP/bip32JP.github.io/blob/master/test_JP_BIP39.json
+func testVectorsJapanese() []vector {
+	return []vector{
+		{
+			entropy:  "00000000000000000000000000000000",
+			mnemonic: "あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら",
+			seed:     "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55",
+		},
+		{
+			entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			mnemonic: "そつう　れきだい　ほんやく　わかす　りくつ　ばいか　ろせん　やちん　そつう　れきだい　ほんやく　わかめ",
+			seed:     "aee025cbe6ca256862f889e48110a6a382365142f7d16f2b9545285b3af64e542143a577e9c144e101a6bdca18f8d97ec3366ebf5b088b1c1af9bc31346e60d9",
+		},
+		{
+			entropy:  "80808080808080808080808080808080",
+			mnemonic: "そとづら　あまど　おおう　あこがれる　いくぶん　けいけん　あたえる　いよく　そとづら　あまど　おおう　あかちゃん",
+			seed:     "e51736736ebdf77eda23fa17e31475fa1d9509c78f1deb6b4aacfbd760a7e2ad769c714352c95143b5c1241985bcb407df36d64e75dd5a2b78ca5d2ba82a3544",
+		},
+		{
+			entropy:  "ffffffffffffffffffffffffffffffff",
+			mnemonic: "われる　われる　われる　われる　われる　われる　われる　われる　われる　われる　われる　ろんぶん",
+			seed:     "4cd2ef49b479af5e1efbbd1e0bdc117f6a29b1010211df4f78e2ed40082865793e57949236c43b9fe591ec70e5bb4298b8b71dc4b267bb96ed4ed282c8f7761c",
+		},
+	}
+}
+
+func TestCzechVectors(t *testing.T) {
+	vectors := []struct {
+		entropy  string
+		mnemonic string
+	}{
+		{
+			"00000000000000000000000000000000",
+			"abdikace abdikace abdikace abdikace abdikace abdikace abdikace abdikace abdikace abdikace abdikace agrese",
+		},
+		{
+			"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			"obrazec znak uznat zubovina zeman skupina zrcadlo vzchopit obrazec znak uznat zubr",
+		},
+		{
+			"808080808080808080808080808080808080808080808080",
+			"obvinit bageta doma amputace bidlo jedle arogance butik obvinit bageta doma amputace bidlo jedle arogance butik obvinit bezinka",
+		},
+		{
+			"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			"zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zvyk zavolat",
+		},
+	}
+
+	for _, vector := range vectors {
+		entropy, err := hex.DecodeString(vector.entropy)
+		assertNil(t, err)
+
+		mnemonic, err := Czech.NewMnemonic(entropy)
+		assertNil(t, err)
+		assertEqualString(t, vector.mnemonic, mnemonic)
+
+		actualEntropy, err := Czech.EntropyFromMnemonic(mnemonic)
+		assertNil(t, err)
+		assertEqualByteSlices(t, entropy, actualEntropy)
+
+		language, err := DetectLanguage(mnemonic)
+		assertNil(t, err)
+		assertTrue(t, language == Czech)
+	}
+
+	assertTrue(t, NewLanguage(words.Czech) == Czech)
+}
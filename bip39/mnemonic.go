@@ -0,0 +1,158 @@
+package bip39
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/grupokindynos/ogen-utils/secret"
+	"golang.org/x/text/unicode/norm"
+)
+
+// WordCountError is returned when a mnemonic doesn't have 12, 15, 18, 21 or
+// 24 words.  It matches ErrInvalidMnemonic with errors.Is.
+type WordCountError struct {
+	// Count is the number of words of the mnemonic.
+	Count int
+}
+
+func (e *WordCountError) Error() string {
+	return fmt.Sprintf("Invalid mnemonic: %d words, must be 12, 15, 18, 21 or 24", e.Count)
+}
+
+// Is reports whether target is ErrInvalidMnemonic.
+func (e *WordCountError) Is(target error) bool {
+	return target == ErrInvalidMnemonic
+}
+
+// UnknownWordError is returned when a word of a mnemonic is not in the
+// wordlist.  It matches ErrInvalidMnemonic and ErrUnknownWord with errors.Is.
+type UnknownWordError struct {
+	// Position is the zero based position of the word in the mnemonic.
+	Position int
+	// Word is the unknown word.
+	Word string
+}
+
+func (e *UnknownWordError) Error() string {
+	return fmt.Sprintf("Invalid mnemonic: word %d `%v` not found in wordlist", e.Position+1, e.Word)
+}
+
+// Is reports whether target is ErrInvalidMnemonic or ErrUnknownWord.
+func (e *UnknownWordError) Is(target error) bool {
+	return target == ErrInvalidMnemonic || target == ErrUnknownWord
+}
+
+// ChecksumError is returned when the checksum bits of a mnemonic don't match
+// its entropy.  It matches ErrInvalidMnemonic and ErrChecksumIncorrect with
+// errors.Is.
+type ChecksumError struct {
+	// Bits is the number of checksum bits of the mnemonic.
+	Bits int
+	// Expected holds the checksum bits computed from the entropy.
+	Expected uint8
+	// Actual holds the checksum bits found in the mnemonic.
+	Actual uint8
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("Checksum incorrect: got %0*b, expected %0*b", e.Bits, e.Actual, e.Bits, e.Expected)
+}
+
+// Is reports whether target is ErrInvalidMnemonic or ErrChecksumIncorrect.
+func (e *ChecksumError) Is(target error) bool {
+	return target == ErrInvalidMnemonic || target == ErrChecksumIncorrect
+}
+
+// Mnemonic is a parsed and validated mnemonic.
+type Mnemonic struct {
+	// Language is the language of the words of the mnemonic.
+	Language *Language
+	// Words are the words of the mnemonic in Unicode NFKD form.
+	Words []string
+	// Indices are the indices of the words in the wordlist.
+	Indices []int
+	// Entropy is the entropy encoded by the mnemonic.
+	Entropy []byte
+	// Checksum holds the checksum bits of the mnemonic in its lowest
+	// ChecksumBits bits.
+	Checksum uint8
+	// ChecksumBits is the number of checksum bits, one for every 32 bits of
+	// entropy.
+	ChecksumBits int
+}
+
+// ParseMnemonic parses a mnemonic using the list of words set by SetWordList.
+// See Language.ParseMnemonic.
+func ParseMnemonic(mnemonic string) (*Mnemonic, error) {
+	return defaultLanguage.ParseMnemonic(mnemonic)
+}
+
+// ParseMnemonic parses a mnemonic in this language and validates its checksum.
+// The mnemonic is normalized to Unicode NFKD form and split on any run of
+// whitespace.
+//
+// A *WordCountError is returned when the number of words is not supported,
+// an *UnknownWordError for the first word not in the wordlist and a
+// *ChecksumError when the checksum doesn't match.  All of them match
+// ErrInvalidMnemonic with errors.Is.
+func (l *Language) ParseMnemonic(mnemonic string) (*Mnemonic, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if !validWordCount(len(words)) {
+		return nil, &WordCountError{Count: len(words)}
+	}
+
+	indices := make([]int, len(words))
+	for i, word := range words {
+		index, ok := l.wordMap[word]
+		if !ok {
+			return nil, &UnknownWordError{Position: i, Word: word}
+		}
+		indices[i] = index
+	}
+
+	// Pack the 11 bits of every word, which are the entropy followed by
+	// one checksum bit for every 32 bits of entropy.
+	b := big.NewInt(0)
+	defer zeroBigInt(b)
+	for _, index := range indices {
+		b.Lsh(b, 11)
+		b.Or(b, big.NewInt(int64(index)))
+	}
+
+	// At most 8 checksum bits, so they all are in the last word.
+	checksumBits := len(words) / 3
+	checksum := uint8(indices[len(indices)-1] & (1<<uint(checksumBits) - 1))
+	b.Rsh(b, uint(checksumBits))
+	entropy := padByteSlice(b.Bytes(), len(words)/3*4)
+
+	expected := computeChecksum(entropy)[0] >> uint(8-checksumBits)
+	if checksum != expected {
+		secret.Zero(entropy)
+		return nil, &ChecksumError{Bits: checksumBits, Expected: expected, Actual: checksum}
+	}
+
+	return &Mnemonic{
+		Language:     l,
+		Words:        words,
+		Indices:      indices,
+		Entropy:      entropy,
+		Checksum:     checksum,
+		ChecksumBits: checksumBits,
+	}, nil
+}
+
+// String returns the words of the mnemonic joined by the separator of its
+// language.
+func (m *Mnemonic) String() string {
+	return strings.Join(m.Words, m.Language.separator)
+}
+
+// Zero clears the entropy and word indices of the mnemonic.
+func (m *Mnemonic) Zero() {
+	secret.Zero(m.Entropy)
+	for i := range m.Indices {
+		m.Indices[i] = 0
+	}
+	m.Checksum = 0
+}
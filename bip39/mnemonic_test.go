@@ -0,0 +1,81 @@
+package bip39
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseMnemonic(t *testing.T) {
+	for _, vector := range testVectors() {
+		m, err := ParseMnemonic(vector.mnemonic)
+		assertNil(t, err)
+
+		assertEqualString(t, vector.entropy, hex.EncodeToString(m.Entropy))
+		assertEqualString(t, vector.mnemonic, m.String())
+		assertTrue(t, m.Language == English)
+		assertEqual(t, len(m.Words)/3, m.ChecksumBits)
+		assertEqual(t, computeChecksum(m.Entropy)[0]>>uint(8-m.ChecksumBits), m.Checksum)
+
+		for i, word := range m.Words {
+			assertEqualString(t, English.wordList[m.Indices[i]], word)
+		}
+	}
+}
+
+func TestParseMnemonicWhitespace(t *testing.T) {
+	mnemonic := "  abandon abandon  abandon abandon abandon abandon\tabandon abandon abandon abandon abandon\nabout "
+	m, err := ParseMnemonic(mnemonic)
+	assertNil(t, err)
+	assertEqualString(t, "00000000000000000000000000000000", hex.EncodeToString(m.Entropy))
+	assertEqualString(t, strings.Join(strings.Fields(mnemonic), " "), m.String())
+}
+
+func TestParseMnemonicWordCount(t *testing.T) {
+	for _, count := range []int{0, 1, 9, 11, 13, 14, 25, 27} {
+		_, err := ParseMnemonic(strings.TrimSpace(strings.Repeat("abandon ", count)))
+
+		var countErr *WordCountError
+		assertTrue(t, errors.As(err, &countErr))
+		assertEqual(t, count, countErr.Count)
+		assertTrue(t, errors.Is(err, ErrInvalidMnemonic))
+	}
+}
+
+func TestParseMnemonicUnknownWord(t *testing.T) {
+	_, err := ParseMnemonic("abandon abandon abandon abandon abandon abandonn abandon abandon abandon abandon abandon about")
+
+	var wordErr *UnknownWordError
+	assertTrue(t, errors.As(err, &wordErr))
+	assertEqual(t, 5, wordErr.Position)
+	assertEqualString(t, "abandonn", wordErr.Word)
+	assertTrue(t, errors.Is(err, ErrInvalidMnemonic))
+	assertTrue(t, errors.Is(err, ErrUnknownWord))
+	assertEqualString(t, "Invalid mnemonic: word 6 `abandonn` not found in wordlist", err.Error())
+}
+
+func TestParseMnemonicChecksum(t *testing.T) {
+	_, err := ParseMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon yellow")
+
+	var checksumErr *ChecksumError
+	assertTrue(t, errors.As(err, &checksumErr))
+	assertEqual(t, 4, checksumErr.Bits)
+	assertEqual(t, uint8(0x0), checksumErr.Expected)
+	assertEqual(t, uint8(0x8), checksumErr.Actual)
+	assertTrue(t, errors.Is(err, ErrInvalidMnemonic))
+	assertTrue(t, errors.Is(err, ErrChecksumIncorrect))
+	assertFalse(t, errors.Is(err, ErrUnknownWord))
+}
+
+func TestMnemonicZero(t *testing.T) {
+	m, err := ParseMnemonic("legal winner thank year wave sausage worth useful legal winner thank yellow")
+	assertNil(t, err)
+
+	m.Zero()
+	assertEqualString(t, "00000000000000000000000000000000", hex.EncodeToString(m.Entropy))
+	for _, index := range m.Indices {
+		assertEqual(t, 0, index)
+	}
+	assertEqual(t, uint8(0), m.Checksum)
+}
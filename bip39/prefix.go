@@ -0,0 +1,122 @@
+package bip39
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// AbbreviationLength is the number of letters which unambiguously identify a
+// word in the English, Spanish, French, Italian and Czech wordlists.
+const AbbreviationLength = 4
+
+var (
+	// ErrUnknownWord is returned when a word or abbreviation doesn't match
+	// any word of the language.
+	ErrUnknownWord = errors.New("word not found in wordlist")
+
+	// ErrAmbiguousAbbreviation is returned when an abbreviation matches more
+	// than one word of the language.
+	ErrAmbiguousAbbreviation = errors.New("abbreviation matches more than one word")
+)
+
+// sortedWords returns the words of the language in Unicode NFC form sorted
+// in ascending order, along with the index of each of them in the wordlist.
+// The result is computed on first use.
+func (l *Language) sortedWords() ([]string, []int) {
+	l.prefixOnce.Do(func() {
+		l.sortedIdx = make([]int, len(l.wordList))
+		for i := range l.sortedIdx {
+			l.sortedIdx[i] = i
+		}
+		sort.Slice(l.sortedIdx, func(i, j int) bool {
+			return norm.NFC.String(l.wordList[l.sortedIdx[i]]) <
+				norm.NFC.String(l.wordList[l.sortedIdx[j]])
+		})
+
+		l.sorted = make([]string, len(l.wordList))
+		for i, idx := range l.sortedIdx {
+			l.sorted[i] = norm.NFC.String(l.wordList[idx])
+		}
+	})
+	return l.sorted, l.sortedIdx
+}
+
+// Complete returns the words of the language starting with the given prefix
+// in ascending order.  Prefixes are compared in Unicode NFC form so accented
+// letters count as a single letter.
+func (l *Language) Complete(prefix string) []string {
+	sorted, sortedIdx := l.sortedWords()
+	prefix = norm.NFC.String(prefix)
+
+	var candidates []string
+	for i := sort.SearchStrings(sorted, prefix); i < len(sorted); i++ {
+		if !strings.HasPrefix(sorted[i], prefix) {
+			break
+		}
+		candidates = append(candidates, l.wordList[sortedIdx[i]])
+	}
+	return candidates
+}
+
+// ExpandWord returns the word of the language identified by the given word or
+// abbreviation.  A complete word is returned as is even when it is also the
+// prefix of other words.  Otherwise the abbreviation must be the prefix of
+// exactly one word.
+func (l *Language) ExpandWord(abbreviation string) (string, error) {
+	if idx, ok := l.WordIndex(abbreviation); ok {
+		return l.wordList[idx], nil
+	}
+
+	candidates := l.Complete(abbreviation)
+	switch len(candidates) {
+	case 0:
+		return "", ErrUnknownWord
+	case 1:
+		return candidates[0], nil
+	default:
+		return "", ErrAmbiguousAbbreviation
+	}
+}
+
+// ExpandMnemonic replaces every abbreviated word of the given mnemonic with
+// the complete word using ExpandWord.  The result can be passed to
+// EntropyFromMnemonic.  The number of words is not checked.
+func (l *Language) ExpandMnemonic(mnemonic string) (string, error) {
+	words := strings.Fields(mnemonic)
+	for i, word := range words {
+		expanded, err := l.ExpandWord(word)
+		if err != nil {
+			return "", fmt.Errorf("word %d `%v`: %w", i+1, word, err)
+		}
+		words[i] = expanded
+	}
+	return strings.Join(words, l.separator), nil
+}
+
+// VerifyUniquePrefixes returns an error when two words of the language start
+// with the same length letters, meaning abbreviations of that length don't
+// identify every word.
+func (l *Language) VerifyUniquePrefixes(length int) error {
+	sorted, _ := l.sortedWords()
+	for i := 1; i < len(sorted); i++ {
+		prev, cur := truncateRunes(sorted[i-1], length), truncateRunes(sorted[i], length)
+		if prev == cur {
+			return fmt.Errorf("words `%v` and `%v` share the prefix `%v`",
+				sorted[i-1], sorted[i], cur)
+		}
+	}
+	return nil
+}
+
+// truncateRunes returns the first n runes of s.
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) > n {
+		runes = runes[:n]
+	}
+	return string(runes)
+}
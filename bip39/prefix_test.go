@@ -0,0 +1,76 @@
+package bip39
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	assertEqualStringSlices(t, []string{"abandon"}, English.Complete("aban"))
+	assertEqualStringSlices(t, []string{"act", "action", "actor", "actress", "actual"}, English.Complete("act"))
+	assertEqualStringSlices(t, nil, English.Complete("xyz"))
+	assertEqual(t, 2048, len(English.Complete("")))
+
+	// Accented letters count as a single letter regardless of how they
+	// are encoded.
+	assertEqualStringSlices(t, []string{"ábaco"}, Spanish.Complete("\u00e1ba"))
+	assertEqualStringSlices(t, []string{"ábaco"}, Spanish.Complete("a\u0301ba"))
+}
+
+func TestExpandWord(t *testing.T) {
+	tests := []struct {
+		abbreviation string
+		word         string
+		err          error
+	}{
+		{"aban", "abandon", nil},
+		{"abandon", "abandon", nil},
+		{"act", "act", nil},
+		{"acti", "action", nil},
+		{"ab", "", ErrAmbiguousAbbreviation},
+		{"xyz", "", ErrUnknownWord},
+	}
+
+	for _, test := range tests {
+		word, err := English.ExpandWord(test.abbreviation)
+		assertEqual(t, test.err, err)
+		assertEqualString(t, test.word, word)
+	}
+}
+
+func TestExpandMnemonic(t *testing.T) {
+	for _, language := range []*Language{English, Spanish, French, Italian, Czech} {
+		entropy, err := NewEntropy(256)
+		assertNil(t, err)
+
+		mnemonic, err := language.NewMnemonic(entropy)
+		assertNil(t, err)
+
+		// Abbreviate every word to its first four letters.
+		words := strings.Fields(mnemonic)
+		for i, word := range words {
+			words[i] = truncateRunes(word, AbbreviationLength)
+		}
+
+		expanded, err := language.ExpandMnemonic(strings.Join(words, " "))
+		assertNil(t, err)
+		assertEqualString(t, mnemonic, expanded)
+
+		actualEntropy, err := language.EntropyFromMnemonic(expanded)
+		assertNil(t, err)
+		assertEqualByteSlices(t, entropy, actualEntropy)
+	}
+
+	_, err := English.ExpandMnemonic("aban aban ab")
+	assertNotNil(t, err)
+	assertTrue(t, strings.Contains(err.Error(), "word 3"))
+}
+
+func TestVerifyUniquePrefixes(t *testing.T) {
+	for _, language := range []*Language{English, Spanish, French, Italian, Czech} {
+		assertNil(t, language.VerifyUniquePrefixes(AbbreviationLength))
+	}
+
+	assertNotNil(t, English.VerifyUniquePrefixes(3))
+	assertNotNil(t, NewLanguage([]string{"abandon", "abandoned"}).VerifyUniquePrefixes(AbbreviationLength))
+}
@@ -0,0 +1,235 @@
+package bip39
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/grupokindynos/ogen-utils/secret"
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxTypoDistance is the largest edit distance between a word of a mnemonic
+// and its replacement considered by SuggestCorrections when every word of the
+// mnemonic is in the wordlist.
+const maxTypoDistance = 2
+
+var (
+	// ErrTooManyUnknownWords is returned when recovering a mnemonic with more
+	// than one word which is not in the wordlist.
+	ErrTooManyUnknownWords = errors.New("more than one word is not in the wordlist")
+
+	// ErrInvalidPosition is returned when the position of a missing word is
+	// out of range.
+	ErrInvalidPosition = errors.New("position of missing word out of range")
+)
+
+// Candidate is a possible correction of a mnemonic which passes the checksum.
+type Candidate struct {
+	// Position is the zero-based position of the replaced or inserted word.
+	Position int
+
+	// Word is the replacement or inserted word.
+	Word string
+
+	// Distance is the edit distance between the word found in the
+	// mnemonic and Word.  It is zero for inserted words.
+	Distance int
+
+	// Mnemonic is the corrected mnemonic.
+	Mnemonic string
+}
+
+// SuggestCorrections returns the possible corrections of a mnemonic with a
+// single misspelled word, ranked by how similar the replacement is to the
+// misspelled word.
+//
+// When one word of the mnemonic is not in the wordlist, every word of the
+// wordlist completing a mnemonic which passes the checksum is returned.  When
+// all words are in the wordlist but the checksum fails, a word may have been
+// misspelled as another valid word, so every word at each position is replaced
+// by the words within an edit distance of two.  No candidates are returned for
+// a valid mnemonic.
+func (l *Language) SuggestCorrections(mnemonic string) ([]Candidate, error) {
+	mnemonicSlice := strings.Fields(norm.NFKD.String(mnemonic))
+	if !validWordCount(len(mnemonicSlice)) {
+		return nil, &WordCountError{Count: len(mnemonicSlice)}
+	}
+
+	indices := make([]int, len(mnemonicSlice))
+	unknown := -1
+	for i, word := range mnemonicSlice {
+		idx, ok := l.wordMap[word]
+		if !ok {
+			if unknown != -1 {
+				return nil, ErrTooManyUnknownWords
+			}
+			unknown = i
+		}
+		indices[i] = idx
+	}
+
+	var candidates []Candidate
+	tryWord := func(pos int, typed string, maxDistance int) {
+		original := indices[pos]
+		for idx, word := range l.wordList {
+			if unknown == -1 && idx == original {
+				continue
+			}
+			distance := editDistance(typed, norm.NFC.String(word))
+			if distance > maxDistance {
+				continue
+			}
+
+			indices[pos] = idx
+			if checksumValid(indices) {
+				candidates = append(candidates, Candidate{
+					Position: pos,
+					Word:     word,
+					Distance: distance,
+					Mnemonic: l.joinIndices(indices),
+				})
+			}
+		}
+		indices[pos] = original
+	}
+
+	if unknown != -1 {
+		tryWord(unknown, norm.NFC.String(mnemonicSlice[unknown]), len(l.wordList))
+	} else if !checksumValid(indices) {
+		for pos, word := range mnemonicSlice {
+			tryWord(pos, norm.NFC.String(word), maxTypoDistance)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Distance < candidates[j].Distance
+	})
+	return candidates, nil
+}
+
+// RecoverMissingWord returns the possible corrections of a mnemonic which is
+// missing a single word.  Every word of the wordlist is inserted at the given
+// zero-based position, or at every position when position is negative, and
+// the completed mnemonics which pass the checksum are returned ordered by
+// position.
+func (l *Language) RecoverMissingWord(mnemonic string, position int) ([]Candidate, error) {
+	mnemonicSlice := strings.Fields(norm.NFKD.String(mnemonic))
+	if !validWordCount(len(mnemonicSlice) + 1) {
+		return nil, &WordCountError{Count: len(mnemonicSlice) + 1}
+	}
+	if position > len(mnemonicSlice) {
+		return nil, ErrInvalidPosition
+	}
+
+	known := make([]int, len(mnemonicSlice))
+	for i, word := range mnemonicSlice {
+		idx, ok := l.wordMap[word]
+		if !ok {
+			return nil, ErrTooManyUnknownWords
+		}
+		known[i] = idx
+	}
+
+	first, last := position, position
+	if position < 0 {
+		first, last = 0, len(known)
+	}
+
+	var candidates []Candidate
+	indices := make([]int, len(known)+1)
+	for pos := first; pos <= last; pos++ {
+		copy(indices, known[:pos])
+		copy(indices[pos+1:], known[pos:])
+		for idx, word := range l.wordList {
+			indices[pos] = idx
+			if checksumValid(indices) {
+				candidates = append(candidates, Candidate{
+					Position: pos,
+					Word:     word,
+					Mnemonic: l.joinIndices(indices),
+				})
+			}
+		}
+	}
+	return candidates, nil
+}
+
+// joinIndices returns the mnemonic made of the words at the given indices.
+func (l *Language) joinIndices(indices []int) string {
+	words := make([]string, len(indices))
+	for i, idx := range indices {
+		words[i] = l.wordList[idx]
+	}
+	return strings.Join(words, l.separator)
+}
+
+// checksumValid returns whether or not the mnemonic made of the words at the
+// given indices passes the checksum.  The number of indices must be a valid
+// mnemonic length.
+func checksumValid(indices []int) bool {
+	// Pack the 11 bits of every index into a big.Int holding the entropy
+	// followed by the checksum.
+	checksummedEntropy := big.NewInt(0)
+	defer zeroBigInt(checksummedEntropy)
+	for _, idx := range indices {
+		checksummedEntropy.Lsh(checksummedEntropy, 11)
+		checksummedEntropy.Or(checksummedEntropy, big.NewInt(int64(idx)))
+	}
+
+	checksumBitSize := uint(len(indices) * 11 / 33)
+	entropyByteSize := len(indices) * 11 * 32 / 33 / 8
+
+	rawEntropy := new(big.Int).Rsh(checksummedEntropy, checksumBitSize)
+	defer zeroBigInt(rawEntropy)
+	rawEntropyBytes := padByteSlice(rawEntropy.Bytes(), entropyByteSize)
+	defer secret.Zero(rawEntropyBytes)
+
+	expected := new(big.Int).SetBytes(addChecksum(rawEntropyBytes))
+	defer zeroBigInt(expected)
+	return expected.Cmp(checksummedEntropy) == 0
+}
+
+// editDistance returns the optimal string alignment distance between a and b,
+// which is the number of single letter insertions, deletions, substitutions
+// and transpositions of adjacent letters needed to turn a into b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// d[i][j] is the distance between the first i letters of a and the
+	// first j letters of b.
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+// minInt returns the smallest of the given integers.
+func minInt(first int, rest ...int) int {
+	min := first
+	for _, v := range rest {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
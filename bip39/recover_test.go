@@ -0,0 +1,128 @@
+package bip39
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSuggestCorrectionsUnknownWord(t *testing.T) {
+	for _, vector := range testVectors() {
+		words := strings.Fields(vector.mnemonic)
+		misspelled := words[5]
+		words[5] = misspelled[:1] + "q" + misspelled[2:]
+
+		candidates, err := English.SuggestCorrections(strings.Join(words, " "))
+		assertNil(t, err)
+		assertTrue(t, len(candidates) > 0)
+
+		// The original word is the closest one passing the checksum,
+		// though others may be as close.
+		assertEqual(t, 1, candidates[0].Distance)
+		assertTrue(t, containsCandidate(candidates, vector.mnemonic))
+
+		for i, candidate := range candidates {
+			assertEqual(t, 5, candidate.Position)
+			assertTrue(t, IsMnemonicValid(candidate.Mnemonic))
+			if i > 0 {
+				assertTrue(t, candidates[i-1].Distance <= candidate.Distance)
+			}
+		}
+	}
+}
+
+func TestSuggestCorrectionsValidWord(t *testing.T) {
+	// "year" misspelled as "wear", which is also in the wordlist.
+	mnemonic := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	typo := strings.Replace(mnemonic, "year", "wear", 1)
+	assertFalse(t, IsMnemonicValid(typo))
+
+	candidates, err := English.SuggestCorrections(typo)
+	assertNil(t, err)
+	assertTrue(t, containsCandidate(candidates, mnemonic))
+	for _, candidate := range candidates {
+		assertTrue(t, candidate.Distance <= maxTypoDistance)
+		assertTrue(t, IsMnemonicValid(candidate.Mnemonic))
+	}
+
+	// Nothing to correct in a valid mnemonic.
+	candidates, err = English.SuggestCorrections(mnemonic)
+	assertNil(t, err)
+	assertEqual(t, 0, len(candidates))
+}
+
+func TestSuggestCorrectionsErrors(t *testing.T) {
+	_, err := English.SuggestCorrections("legal winner thank")
+	assertTrue(t, errors.Is(err, ErrInvalidMnemonic))
+	countErr, ok := err.(*WordCountError)
+	assertTrue(t, ok)
+	assertEqual(t, 3, countErr.Count)
+
+	_, err = English.SuggestCorrections("legal winnr thank year wave sausage worth useful legal winner thank yellw")
+	assertEqual(t, ErrTooManyUnknownWords, err)
+}
+
+func TestRecoverMissingWord(t *testing.T) {
+	for _, vector := range testVectors()[:6] {
+		words := strings.Fields(vector.mnemonic)
+		missing := append(append([]string{}, words[:3]...), words[4:]...)
+
+		candidates, err := English.RecoverMissingWord(strings.Join(missing, " "), 3)
+		assertNil(t, err)
+		assertTrue(t, containsCandidate(candidates, vector.mnemonic))
+		for _, candidate := range candidates {
+			assertEqual(t, 3, candidate.Position)
+			assertTrue(t, IsMnemonicValid(candidate.Mnemonic))
+		}
+
+		candidates, err = English.RecoverMissingWord(strings.Join(missing, " "), -1)
+		assertNil(t, err)
+		assertTrue(t, containsCandidate(candidates, vector.mnemonic))
+		for i := 1; i < len(candidates); i++ {
+			assertTrue(t, candidates[i-1].Position <= candidates[i].Position)
+		}
+	}
+
+	_, err := English.RecoverMissingWord("legal winner thank year wave sausage worth useful legal winner thank yellow", -1)
+	assertTrue(t, errors.Is(err, ErrInvalidMnemonic))
+	countErr, ok := err.(*WordCountError)
+	assertTrue(t, ok)
+	assertEqual(t, 13, countErr.Count)
+
+	_, err = English.RecoverMissingWord("legal winner thank year wave sausage worth useful legal winner thank", 12)
+	assertEqual(t, ErrInvalidPosition, err)
+
+	_, err = English.RecoverMissingWord("legal winner thank year wave sausage worth useful legal winnr thank", -1)
+	assertEqual(t, ErrTooManyUnknownWords, err)
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		distance int
+	}{
+		{"", "", 0},
+		{"abandon", "abandon", 0},
+		{"abandon", "abandn", 1},
+		{"abandon", "abandonn", 1},
+		{"abandon", "abendon", 1},
+		{"abandon", "abadnon", 1},
+		{"abandon", "ability", 5},
+		{"ábaco", "abaco", 1},
+		{"", "zoo", 3},
+	}
+
+	for _, test := range tests {
+		assertEqual(t, test.distance, editDistance(test.a, test.b))
+		assertEqual(t, test.distance, editDistance(test.b, test.a))
+	}
+}
+
+func containsCandidate(candidates []Candidate, mnemonic string) bool {
+	for _, candidate := range candidates {
+		if candidate.Mnemonic == mnemonic {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package words
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ListLength is the number of words of every bip39 wordlist.
+const ListLength = 2048
+
+var (
+	// ErrInvalidLength is returned when a wordlist doesn't have 2048 words.
+	ErrInvalidLength = errors.New("wordlist must have 2048 words")
+
+	// ErrDuplicateWord is returned when a word appears twice in a wordlist.
+	ErrDuplicateWord = errors.New("wordlist has a duplicate word")
+
+	// ErrNotSorted is returned when a wordlist which must be sorted isn't.
+	ErrNotSorted = errors.New("wordlist is not sorted")
+
+	// ErrDigestMismatch is returned when a wordlist doesn't match its
+	// upstream digest.
+	ErrDigestMismatch = errors.New("wordlist digest does not match upstream")
+)
+
+// List describes a wordlist along with the properties it must satisfy.
+type List struct {
+	// Name is the name of the upstream wordlist file without extension.
+	Name string
+	// Words are the words of the list.
+	Words []string
+	// Digest is the hex encoded SHA256 digest of the upstream wordlist
+	// file, as computed by Digest.
+	Digest string
+	// Sorted reports whether the words are sorted in byte order.  The
+	// Spanish, French and Czech lists follow their alphabetical order
+	// instead, and the Japanese and Chinese lists are not sorted.
+	Sorted bool
+}
+
+// Lists holds every wordlist of the package.  The digests are those of the
+// wordlist files at https://github.com/bitcoin/bips/tree/master/bip-0039.
+var Lists = []List{
+	{"english", English, "2f5eed53a4727b4bf8880d8f3f199efc90e58503646d9ff8eff3a2ed3b24dbda", true},
+	{"japanese", Japanese, "2eed0aef492291e061633d7ad8117f1a2b03eb80a29d0e4e3117ac2528d05ffd", false},
+	{"korean", Korean, "9e95f86c167de88f450f0aaf89e87f6624a57f973c67b516e338e8e8b8897f60", true},
+	{"spanish", Spanish, "46846a5a0139d1e3cb77293e521c2865f7bcdb82c44e8d0a06a2cd0ecba48c0b", false},
+	{"chinese_simplified", ChineseSimplified, "5c5942792bd8340cb8b27cd592f1015edf56a8c5b26276ee18a482428e7c5726", false},
+	{"chinese_traditional", ChineseTraditional, "417b26b3d8500a4ae3d59717d7011952db6fc2fb84b807f3f94ac734e89c1b5f", false},
+	{"french", French, "ebc3959ab7801a1df6bac4fa7d970652f1df76b683cd2f4003c941c63d517e59", false},
+	{"italian", Italian, "d392c49fdb700a24cd1fceb237c1f65dcc128f6b34a8aacb58b59384b5c648c2", true},
+	{"czech", Czech, "7e80e161c3e93d9554c2efb78d4e3cebf8fc727e9c52e03b83b94406bdcc95fc", false},
+}
+
+// Digest returns the hex encoded SHA256 digest of the words in the format of
+// the upstream wordlist files: one word per line in Unicode NFKD form, each
+// followed by a newline.  The words of this package are stored in NFC form, so
+// they are normalized first.
+func Digest(list []string) string {
+	h := sha256.New()
+	for _, word := range list {
+		h.Write(norm.NFKD.Bytes([]byte(word)))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Verify checks that the list has 2048 unique words, that they are sorted if
+// required and that they match the upstream digest.
+func (l *List) Verify() error {
+	if len(l.Words) != ListLength {
+		return fmt.Errorf("%s: %w", l.Name, ErrInvalidLength)
+	}
+
+	seen := make(map[string]bool, len(l.Words))
+	for i, word := range l.Words {
+		if seen[word] {
+			return fmt.Errorf("%s: word %d `%v`: %w", l.Name, i, word, ErrDuplicateWord)
+		}
+		seen[word] = true
+		if l.Sorted && i > 0 && l.Words[i-1] >= word {
+			return fmt.Errorf("%s: word %d `%v`: %w", l.Name, i, word, ErrNotSorted)
+		}
+	}
+
+	if Digest(l.Words) != l.Digest {
+		return fmt.Errorf("%s: %w", l.Name, ErrDigestMismatch)
+	}
+	return nil
+}
+
+// Verify checks every wordlist of the package with List.Verify.
+func Verify() error {
+	for i := range Lists {
+		if err := Lists[i].Verify(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
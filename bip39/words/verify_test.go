@@ -0,0 +1,60 @@
+package words
+
+import (
+	"errors"
+	"testing"
+)
+
+// upstreamDigests are the SHA256 digests of the wordlist files at
+// https://github.com/bitcoin/bips/tree/master/bip-0039.
+var upstreamDigests = map[string]string{
+	"english":             "2f5eed53a4727b4bf8880d8f3f199efc90e58503646d9ff8eff3a2ed3b24dbda",
+	"japanese":            "2eed0aef492291e061633d7ad8117f1a2b03eb80a29d0e4e3117ac2528d05ffd",
+	"korean":              "9e95f86c167de88f450f0aaf89e87f6624a57f973c67b516e338e8e8b8897f60",
+	"spanish":             "46846a5a0139d1e3cb77293e521c2865f7bcdb82c44e8d0a06a2cd0ecba48c0b",
+	"chinese_simplified":  "5c5942792bd8340cb8b27cd592f1015edf56a8c5b26276ee18a482428e7c5726",
+	"chinese_traditional": "417b26b3d8500a4ae3d59717d7011952db6fc2fb84b807f3f94ac734e89c1b5f",
+	"french":              "ebc3959ab7801a1df6bac4fa7d970652f1df76b683cd2f4003c941c63d517e59",
+	"italian":             "d392c49fdb700a24cd1fceb237c1f65dcc128f6b34a8aacb58b59384b5c648c2",
+	"czech":               "7e80e161c3e93d9554c2efb78d4e3cebf8fc727e9c52e03b83b94406bdcc95fc",
+}
+
+func TestUpstreamDigests(t *testing.T) {
+	if len(Lists) != len(upstreamDigests) {
+		t.Fatalf("expected %d lists, got %d", len(upstreamDigests), len(Lists))
+	}
+	for _, l := range Lists {
+		if got := Digest(l.Words); got != upstreamDigests[l.Name] {
+			t.Errorf("%s: digest %s does not match upstream %s", l.Name, got, upstreamDigests[l.Name])
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	if err := Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyErrors(t *testing.T) {
+	english := Lists[0]
+
+	tests := []struct {
+		name   string
+		mutate func([]string) []string
+		err    error
+	}{
+		{"short", func(w []string) []string { return w[:2047] }, ErrInvalidLength},
+		{"duplicate", func(w []string) []string { w[1] = w[0]; return w }, ErrDuplicateWord},
+		{"unsorted", func(w []string) []string { w[0], w[1] = w[1], w[0]; return w }, ErrNotSorted},
+		{"edited", func(w []string) []string { w[2047] = "zzz"; return w }, ErrDigestMismatch},
+	}
+
+	for _, test := range tests {
+		l := english
+		l.Words = test.mutate(append([]string(nil), english.Words...))
+		if err := l.Verify(); !errors.Is(err, test.err) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.err, err)
+		}
+	}
+}
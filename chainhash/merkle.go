@@ -0,0 +1,179 @@
+package chainhash
+
+import "errors"
+
+// HashFunc hashes data to a Hash, such as DoubleHashH, HashH or Sha3H.
+type HashFunc func(b []byte) Hash
+
+var (
+	// ErrEmptyMerkleTree describes an error that indicates a Merkle tree
+	// was built without leaves.
+	ErrEmptyMerkleTree = errors.New("merkle tree has no leaves")
+
+	// ErrMutatedMerkleTree describes an error that indicates two sibling
+	// nodes of a Merkle tree are identical.  See NewMerkleTree.
+	ErrMutatedMerkleTree = errors.New("merkle tree has identical siblings")
+
+	// ErrMerkleIndex describes an error that indicates a leaf index outside
+	// of the tree.
+	ErrMerkleIndex = errors.New("merkle leaf index out of range")
+)
+
+// MerkleTree is a binary Merkle tree over a list of hashes, built the way
+// bitcoin builds the Merkle root of the transactions of a block.  Each node is
+// the hash of its two children concatenated, and the last node of a level
+// with an odd number of nodes is paired with itself.
+type MerkleTree struct {
+	hashFunc HashFunc
+
+	// levels holds every level of the tree, from the leaves to the root.
+	levels [][]Hash
+}
+
+// hashMerkleBranches returns the parent of two nodes.
+func hashMerkleBranches(hashFunc HashFunc, left, right *Hash) Hash {
+	var buf [HashSize * 2]byte
+	copy(buf[:HashSize], left[:])
+	copy(buf[HashSize:], right[:])
+	return hashFunc(buf[:])
+}
+
+// NewMerkleTree builds the Merkle tree of the leaves using the hash function,
+// or DoubleHashH when nil.  A single leaf is its own root.
+//
+// Pairing the last node with itself makes the list of leaves ambiguous: the
+// leaves [a b c] and [a b c c] have the same root (CVE-2012-2459).  To prevent
+// that, ErrMutatedMerkleTree is returned when any two siblings are identical,
+// which can only happen when the list of leaves repeats a hash at the end of
+// a level.  Callers whose leaves may legitimately repeat must make them unique,
+// for example by hashing the index of each leaf into it.
+func NewMerkleTree(leaves []Hash, hashFunc HashFunc) (*MerkleTree, error) {
+	if len(leaves) == 0 {
+		return nil, ErrEmptyMerkleTree
+	}
+	if hashFunc == nil {
+		hashFunc = DoubleHashH
+	}
+
+	level := make([]Hash, len(leaves))
+	copy(level, leaves)
+	levels := [][]Hash{level}
+	for len(level) > 1 {
+		next := make([]Hash, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next[i/2] = hashMerkleBranches(hashFunc, &level[i], &level[i])
+				continue
+			}
+			if level[i] == level[i+1] {
+				return nil, ErrMutatedMerkleTree
+			}
+			next[i/2] = hashMerkleBranches(hashFunc, &level[i], &level[i+1])
+		}
+		levels = append(levels, next)
+		level = next
+	}
+
+	return &MerkleTree{hashFunc: hashFunc, levels: levels}, nil
+}
+
+// MerkleRoot returns the Merkle root of the leaves like NewMerkleTree, without
+// keeping the tree.
+func MerkleRoot(leaves []Hash, hashFunc HashFunc) (Hash, error) {
+	tree, err := NewMerkleTree(leaves, hashFunc)
+	if err != nil {
+		return Hash{}, err
+	}
+	return tree.Root(), nil
+}
+
+// Root returns the root of the tree.
+func (t *MerkleTree) Root() Hash {
+	return t.levels[len(t.levels)-1][0]
+}
+
+// NumLeaves returns the number of leaves of the tree.
+func (t *MerkleTree) NumLeaves() int {
+	return len(t.levels[0])
+}
+
+// Leaf returns the leaf at an index.
+func (t *MerkleTree) Leaf(index int) Hash {
+	return t.levels[0][index]
+}
+
+// Proof returns the proof that the leaf at an index is included in the tree.
+func (t *MerkleTree) Proof(index int) (*MerkleProof, error) {
+	if index < 0 || index >= t.NumLeaves() {
+		return nil, ErrMerkleIndex
+	}
+
+	proof := &MerkleProof{
+		Index:     index,
+		NumLeaves: t.NumLeaves(),
+	}
+	for _, level := range t.levels[:len(t.levels)-1] {
+		// The last node of an odd level is paired with itself, which
+		// is implied by the number of leaves rather than included.
+		if sibling := index ^ 1; sibling < len(level) {
+			proof.Siblings = append(proof.Siblings, level[sibling])
+		}
+		index /= 2
+	}
+	return proof, nil
+}
+
+// MerkleProof proves the inclusion of a leaf in a Merkle tree.  Siblings are
+// the siblings of the nodes on the path from the leaf to the root, except for
+// nodes paired with themselves.  NumLeaves is the number of leaves claimed by
+// the prover, which Verify checks against the number the verifier trusts.
+type MerkleProof struct {
+	Index     int
+	NumLeaves int
+	Siblings  []Hash
+}
+
+// Verify returns whether or not the proof shows that the leaf is included at
+// the proof's index in a tree of numLeaves leaves with the given root, built
+// with the hash function, or DoubleHashH when nil.
+//
+// Leaves and inner nodes are hashed the same way, as in blocks, so an inner
+// node of a tree is also the leaf of a smaller tree with the same root.  The
+// number of leaves must therefore come from a source the verifier trusts, such
+// as the transaction count committed to by a block, and not from the prover.
+// The proof must have exactly one sibling for every level of that tree, except
+// for nodes paired with themselves.
+//
+// Like NewMerkleTree, proofs with a sibling identical to the node on the path
+// are rejected, so a leaf can't be proven at the position of a duplicated
+// node.
+func (p *MerkleProof) Verify(leaf, root Hash, numLeaves int, hashFunc HashFunc) bool {
+	if p.NumLeaves != numLeaves || p.Index < 0 || p.Index >= numLeaves {
+		return false
+	}
+	if hashFunc == nil {
+		hashFunc = DoubleHashH
+	}
+
+	node := leaf
+	index, width := p.Index, numLeaves
+	siblings := p.Siblings
+	for width > 1 {
+		if index^1 >= width {
+			node = hashMerkleBranches(hashFunc, &node, &node)
+		} else {
+			if len(siblings) == 0 || siblings[0] == node {
+				return false
+			}
+			if index%2 == 0 {
+				node = hashMerkleBranches(hashFunc, &node, &siblings[0])
+			} else {
+				node = hashMerkleBranches(hashFunc, &siblings[0], &node)
+			}
+			siblings = siblings[1:]
+		}
+		index /= 2
+		width = (width + 1) / 2
+	}
+	return len(siblings) == 0 && node == root
+}
@@ -0,0 +1,246 @@
+package chainhash
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// reversedHash decodes a hash displayed byte-reversed, the way bitcoin shows
+// transaction and block hashes.
+func reversedHash(t *testing.T, s string) Hash {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("invalid hash %v: %v", s, err)
+	}
+	var h Hash
+	for i := range b {
+		h[HashSize-1-i] = b[i]
+	}
+	return h
+}
+
+// testLeaves returns n distinct leaves.
+func testLeaves(n int) []Hash {
+	leaves := make([]Hash, n)
+	for i := range leaves {
+		leaves[i] = HashH([]byte{byte(i), byte(i >> 8)})
+	}
+	return leaves
+}
+
+// TestMerkleRootBlock100000 tests the Merkle root of the transactions of block
+// 100000 of the bitcoin main network.
+func TestMerkleRootBlock100000(t *testing.T) {
+	leaves := []Hash{
+		reversedHash(t, "8c14f0db3df150123e6f3dbbf30f8b955a8249b62ac1d1ff16284aefa3d06d87"),
+		reversedHash(t, "fff2525b8931402dd09222c50775608f75787bd2b87e56995a7bdd30f79702c4"),
+		reversedHash(t, "6359f0868171b1d194cbee1af2f16ea598ae8fad666d9b012c8ed2b79a236ec4"),
+		reversedHash(t, "e9a66845e05d5abc0ad04ec80f774a7e585c6e8db975962d069a522137b80c1d"),
+	}
+	want := reversedHash(t, "f3e94742aca4b5ef85488dc37c06c3282295ffec960994b2c0d5ac2a25a95766")
+
+	root, err := MerkleRoot(leaves, DoubleHashH)
+	if err != nil {
+		t.Fatalf("MerkleRoot: %v", err)
+	}
+	if root != want {
+		t.Errorf("MerkleRoot: got %v, want %v", root, want)
+	}
+
+	// The default hash function is DoubleHashH.
+	root, err = MerkleRoot(leaves, nil)
+	if err != nil || root != want {
+		t.Errorf("MerkleRoot with default hash: got %v (%v), want %v", root, err, want)
+	}
+
+	// Another hash function gives another root.
+	root, err = MerkleRoot(leaves, Sha3H)
+	if err != nil || root == want {
+		t.Errorf("MerkleRoot with Sha3H: got %v (%v)", root, err)
+	}
+}
+
+// TestMerkleTree tests building trees and their structure.
+func TestMerkleTree(t *testing.T) {
+	if _, err := NewMerkleTree(nil, nil); err != ErrEmptyMerkleTree {
+		t.Errorf("NewMerkleTree: got %v, want ErrEmptyMerkleTree", err)
+	}
+
+	leaf := HashH([]byte("leaf"))
+	tree, err := NewMerkleTree([]Hash{leaf}, nil)
+	if err != nil {
+		t.Fatalf("NewMerkleTree: %v", err)
+	}
+	if tree.Root() != leaf {
+		t.Errorf("Root of a single leaf: got %v, want %v", tree.Root(), leaf)
+	}
+
+	// Three leaves pair the last one with itself.
+	leaves := testLeaves(3)
+	tree, err = NewMerkleTree(leaves, HashH)
+	if err != nil {
+		t.Fatalf("NewMerkleTree: %v", err)
+	}
+	left := hashMerkleBranches(HashH, &leaves[0], &leaves[1])
+	right := hashMerkleBranches(HashH, &leaves[2], &leaves[2])
+	if want := hashMerkleBranches(HashH, &left, &right); tree.Root() != want {
+		t.Errorf("Root: got %v, want %v", tree.Root(), want)
+	}
+	if tree.NumLeaves() != 3 || tree.Leaf(2) != leaves[2] {
+		t.Errorf("unexpected leaves")
+	}
+
+	// The tree keeps its own copy of the leaves.
+	leaves[0] = Hash{}
+	if tree.Leaf(0) == leaves[0] {
+		t.Errorf("tree shares its leaves with the caller")
+	}
+}
+
+// TestMerkleTreeMutated tests that the duplicated leaves of CVE-2012-2459 are
+// rejected.
+func TestMerkleTreeMutated(t *testing.T) {
+	leaves := testLeaves(6)
+	if _, err := NewMerkleTree(leaves[:3], nil); err != nil {
+		t.Fatalf("NewMerkleTree: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		leaves []Hash
+	}{
+		{
+			name:   "duplicated last leaf",
+			leaves: []Hash{leaves[0], leaves[1], leaves[2], leaves[2]},
+		},
+		{
+			name:   "duplicated last pair",
+			leaves: append(leaves[:6:6], leaves[4], leaves[5]),
+		},
+		{
+			name:   "identical leaves",
+			leaves: []Hash{leaves[0], leaves[0]},
+		},
+	}
+
+	for _, test := range tests {
+		if _, err := NewMerkleTree(test.leaves, nil); err != ErrMutatedMerkleTree {
+			t.Errorf("%s: got %v, want ErrMutatedMerkleTree", test.name, err)
+		}
+	}
+}
+
+// TestMerkleProof tests generating and verifying inclusion proofs.
+func TestMerkleProof(t *testing.T) {
+	for n := 1; n <= 17; n++ {
+		leaves := testLeaves(n)
+		tree, err := NewMerkleTree(leaves, nil)
+		if err != nil {
+			t.Fatalf("NewMerkleTree(%d): %v", n, err)
+		}
+		root := tree.Root()
+
+		for i, leaf := range leaves {
+			proof, err := tree.Proof(i)
+			if err != nil {
+				t.Fatalf("Proof(%d) of %d: %v", i, n, err)
+			}
+			if !proof.Verify(leaf, root, n, nil) {
+				t.Errorf("Verify(%d) of %d: valid proof rejected", i, n)
+			}
+			if n > 1 && proof.Verify(leaves[(i+1)%n], root, n, nil) {
+				t.Errorf("Verify(%d) of %d: accepted another leaf", i, n)
+			}
+			if proof.Verify(leaf, HashH(root[:]), n, nil) {
+				t.Errorf("Verify(%d) of %d: accepted another root", i, n)
+			}
+			if proof.Verify(leaf, root, n+1, nil) {
+				t.Errorf("Verify(%d) of %d: accepted another number of leaves", i, n)
+			}
+			if n > 1 && proof.Verify(leaf, root, n, Sha3H) {
+				t.Errorf("Verify(%d) of %d: accepted another hash function", i, n)
+			}
+			if len(proof.Siblings) > 0 {
+				proof.Siblings[0][0] ^= 1
+				if proof.Verify(leaf, root, n, nil) {
+					t.Errorf("Verify(%d) of %d: accepted a modified sibling", i, n)
+				}
+				proof.Siblings[0][0] ^= 1
+			}
+		}
+
+		if _, err := tree.Proof(n); err != ErrMerkleIndex {
+			t.Errorf("Proof(%d) of %d: got %v, want ErrMerkleIndex", n, n, err)
+		}
+		if _, err := tree.Proof(-1); err != ErrMerkleIndex {
+			t.Errorf("Proof(-1) of %d: got %v, want ErrMerkleIndex", n, err)
+		}
+	}
+}
+
+// TestMerkleProofMutated tests that a leaf can't be proven at the position of a
+// duplicated node.
+func TestMerkleProofMutated(t *testing.T) {
+	leaves := testLeaves(3)
+	tree, err := NewMerkleTree(leaves, nil)
+	if err != nil {
+		t.Fatalf("NewMerkleTree: %v", err)
+	}
+	proof, err := tree.Proof(2)
+	if err != nil {
+		t.Fatalf("Proof: %v", err)
+	}
+
+	// The same root with a fourth leaf identical to the third.
+	forged := &MerkleProof{
+		Index:     3,
+		NumLeaves: 4,
+		Siblings:  append([]Hash{leaves[2]}, proof.Siblings...),
+	}
+	if forged.Verify(leaves[2], tree.Root(), 4, nil) {
+		t.Errorf("Verify: accepted a leaf at a duplicated position")
+	}
+
+	// An index beyond the leaves.
+	outside := *proof
+	outside.Index = 3
+	if outside.Verify(leaves[2], tree.Root(), 3, nil) {
+		t.Errorf("Verify: accepted an index beyond the leaves")
+	}
+
+	// Extra siblings.
+	extra := *proof
+	extra.Siblings = append(append([]Hash(nil), proof.Siblings...), leaves[0])
+	if extra.Verify(leaves[2], tree.Root(), 3, nil) {
+		t.Errorf("Verify: accepted extra siblings")
+	}
+}
+
+// TestMerkleProofInnerNode tests that an inner node can't be proven as the leaf
+// of a smaller tree with the same root.
+func TestMerkleProofInnerNode(t *testing.T) {
+	leaves := testLeaves(4)
+	tree, err := NewMerkleTree(leaves, nil)
+	if err != nil {
+		t.Fatalf("NewMerkleTree: %v", err)
+	}
+	n01 := DoubleHashH(append(leaves[0][:], leaves[1][:]...))
+	n23 := DoubleHashH(append(leaves[2][:], leaves[3][:]...))
+
+	forged := &MerkleProof{Index: 0, NumLeaves: 2, Siblings: []Hash{n23}}
+	if forged.Verify(n01, tree.Root(), 4, nil) {
+		t.Errorf("Verify: accepted an inner node claiming a smaller tree")
+	}
+
+	// With the claimed count the inner node is a leaf of a 2 leaf tree,
+	// which is why the count must be trusted.
+	if !forged.Verify(n01, tree.Root(), 2, nil) {
+		t.Errorf("Verify: rejected the inner node as a leaf of a 2 leaf tree")
+	}
+
+	// A proof with too few siblings for the trusted count.
+	short := &MerkleProof{Index: 0, NumLeaves: 4, Siblings: []Hash{n23}}
+	if short.Verify(n01, tree.Root(), 4, nil) {
+		t.Errorf("Verify: accepted a proof with too few siblings")
+	}
+}
@@ -5,4 +5,5 @@ go 1.13
 require (
 	github.com/phoreproject/bls v0.0.0-20191211001008-9d5f85bf4a9b
 	golang.org/x/crypto v0.0.0-20191206172530-e9b2fee46413
+	golang.org/x/text v0.3.7
 )
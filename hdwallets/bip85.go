@@ -0,0 +1,190 @@
+package hdwallets
+
+// References:
+//   [BIP85]: Deterministic Entropy From BIP32 Keychains
+//   https://github.com/bitcoin/bips/blob/master/bip-0085.mediawiki
+//
+// The derivation follows [BIP85], but since the keychain is built on BLS keys
+// the derived entropy differs from the secp256k1 test vectors of [BIP85].
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"errors"
+
+	"github.com/grupokindynos/ogen-utils/bip39"
+	"github.com/grupokindynos/ogen-utils/secret"
+	"github.com/phoreproject/bls/g1pubs"
+)
+
+const (
+	// BIP85Purpose is the purpose index of every [BIP85] derivation path,
+	// m/83696968'/app'/...
+	BIP85Purpose = 83696968
+
+	// BIP85AppBIP39 is the application number for bip39 mnemonics.  The
+	// path is m/83696968'/39'/language'/words'/index'.
+	BIP85AppBIP39 = 39
+
+	// BIP85AppXPRV is the application number for child extended private
+	// keys.  The path is m/83696968'/32'/index'.
+	BIP85AppXPRV = 32
+
+	// BIP85AppHex is the application number for raw hex entropy.  The path
+	// is m/83696968'/128169'/bytes'/index'.
+	BIP85AppHex = 128169
+
+	// BIP85MinHexBytes is the minimum number of bytes of hex entropy.
+	BIP85MinHexBytes = 16
+
+	// BIP85MaxHexBytes is the maximum number of bytes of hex entropy.
+	BIP85MaxHexBytes = 64
+)
+
+var (
+	// ErrBIP85UnknownLanguage describes an error in which the caller asked
+	// for a mnemonic in a language without a [BIP85] language code.
+	ErrBIP85UnknownLanguage = errors.New("language has no BIP85 code")
+
+	// ErrBIP85InvalidWordCount describes an error in which the caller asked
+	// for a mnemonic with a word count not allowed by bip39.
+	ErrBIP85InvalidWordCount = errors.New("mnemonic word count must be " +
+		"12, 15, 18, 21 or 24")
+
+	// ErrBIP85InvalidHexLen describes an error in which the caller asked for
+	// hex entropy outside of the allowed length.
+	ErrBIP85InvalidHexLen = errors.New("hex entropy length must be between " +
+		"16 and 64 bytes")
+
+	// ErrBIP85InvalidIndex describes an error in which a path index is
+	// already hardened and can't be hardened again.
+	ErrBIP85InvalidIndex = errors.New("BIP85 path indices must be below " +
+		"2^31")
+)
+
+// bip85Key is the HMAC key used to turn a derived private key into entropy.
+var bip85Key = []byte("bip-entropy-from-k")
+
+// BIP85LanguageCode returns the [BIP85] code of a bip39 language, which is the
+// language index of the mnemonic derivation path.  Code 9 is Portuguese,
+// which is not supported since its wordlist is not included yet.
+func BIP85LanguageCode(lang *bip39.Language) (uint32, bool) {
+	codes := []*bip39.Language{
+		bip39.English,
+		bip39.Japanese,
+		bip39.Korean,
+		bip39.Spanish,
+		bip39.ChineseSimplified,
+		bip39.ChineseTraditional,
+		bip39.French,
+		bip39.Italian,
+		bip39.Czech,
+	}
+	for i, l := range codes {
+		if l == lang {
+			return uint32(i), true
+		}
+	}
+	return 0, false
+}
+
+// BIP85Entropy derives 64 bytes of entropy from the hardened path
+// m/83696968'/path... relative to this extended key.  The indices of path are
+// hardened by the function, so they must be below HardenedKeyStart.
+//
+// The extended key must be a private extended key.  ErrNotPrivExtKey is
+// returned otherwise.
+func (k *ExtendedKey) BIP85Entropy(path ...uint32) ([]byte, error) {
+	if !k.isPrivate {
+		return nil, ErrNotPrivExtKey
+	}
+	for _, i := range path {
+		if i >= HardenedKeyStart {
+			return nil, ErrBIP85InvalidIndex
+		}
+	}
+
+	// The intermediate keys are only needed to reach the final one, so
+	// they are derived without being remembered by k and each is cleared
+	// once its child is derived.
+	key, err := k.derive(HardenedKeyStart + BIP85Purpose)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { key.Zero() }()
+
+	for _, i := range path {
+		child, err := key.derive(HardenedKeyStart + i)
+		key.Zero()
+		if err != nil {
+			return nil, err
+		}
+		key = child
+	}
+
+	mac := hmac.New(sha512.New, bip85Key)
+	mac.Write(key.key)
+	return mac.Sum(nil), nil
+}
+
+// BIP85Mnemonic derives the child bip39 mnemonic with the given number of
+// words in the given language at index.
+func (k *ExtendedKey) BIP85Mnemonic(lang *bip39.Language, words int, index uint32) (string, error) {
+	code, ok := BIP85LanguageCode(lang)
+	if !ok {
+		return "", ErrBIP85UnknownLanguage
+	}
+	switch words {
+	case 12, 15, 18, 21, 24:
+	default:
+		return "", ErrBIP85InvalidWordCount
+	}
+
+	entropy, err := k.BIP85Entropy(BIP85AppBIP39, code, uint32(words), index)
+	if err != nil {
+		return "", err
+	}
+	defer secret.Zero(entropy)
+
+	// Every word encodes 11 bits, 1 of which out of each 33 is checksum.
+	return lang.NewMnemonic(entropy[:words*4/3])
+}
+
+// BIP85Hex derives numBytes bytes of raw entropy at index.
+func (k *ExtendedKey) BIP85Hex(numBytes int, index uint32) ([]byte, error) {
+	if numBytes < BIP85MinHexBytes || numBytes > BIP85MaxHexBytes {
+		return nil, ErrBIP85InvalidHexLen
+	}
+
+	entropy, err := k.BIP85Entropy(BIP85AppHex, uint32(numBytes), index)
+	if err != nil {
+		return nil, err
+	}
+	return entropy[:numBytes], nil
+}
+
+// BIP85ExtendedKey derives the child master extended private key at index.
+// Per [BIP85] the first 32 bytes of entropy are the chain code and the last 32
+// bytes are the private key.  The returned key uses the derivation mode of this
+// extended key.
+func (k *ExtendedKey) BIP85ExtendedKey(index uint32, net *NetPrefix) (*ExtendedKey, error) {
+	entropy, err := k.BIP85Entropy(BIP85AppXPRV, index)
+	if err != nil {
+		return nil, err
+	}
+	defer secret.Zero(entropy)
+
+	chainCode := make([]byte, 32)
+	copy(chainCode, entropy[:32])
+
+	var secretKeyBytes [32]byte
+	copy(secretKeyBytes[:], entropy[32:])
+	defer secret.Zero(secretKeyBytes[:])
+	secretKeySer := g1pubs.DeriveSecretKey(secretKeyBytes).Serialize()
+
+	parentFP := []byte{0x00, 0x00, 0x00, 0x00}
+	child := NewExtendedKey(net.ExtPriv, secretKeySer[:], chainCode,
+		parentFP, 0, 0, true)
+	child.mode = k.mode
+	return child, nil
+}
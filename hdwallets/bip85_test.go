@@ -0,0 +1,157 @@
+package hdwallets_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"testing"
+
+	"github.com/grupokindynos/ogen-utils/bip39"
+	"github.com/grupokindynos/ogen-utils/hdwallets"
+)
+
+// bip85Master returns the master key used by the BIP85 test vectors.  The
+// vectors were generated by this implementation since the secp256k1 vectors of
+// BIP85 don't apply to BLS keys.
+func bip85Master(t *testing.T) *hdwallets.ExtendedKey {
+	var seed [32]byte
+	NewXORShift(85).Read(seed[:])
+	master, err := hdwallets.NewMaster(seed[:], polisNetPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return master
+}
+
+func TestBIP85Vectors(t *testing.T) {
+	master := bip85Master(t)
+
+	mnemonics := []struct {
+		lang     *bip39.Language
+		words    int
+		index    uint32
+		mnemonic string
+	}{
+		{bip39.English, 12, 0, "slow energy impose boil stairs clump unknown project enter kidney family brass"},
+		{bip39.English, 24, 0, "zone announce glad discover tobacco arrest ignore torch online spin leader region rival setup afford patch endorse merry regret next shaft coconut math kitchen"},
+		{bip39.Japanese, 12, 0, "ふくざつ　そろう　へいねつ　てうち　けんげん　あわてる　たこく　りよう　かいぞうど　けむり　はちみつ　げつれい"},
+	}
+	for _, test := range mnemonics {
+		mnemonic, err := master.BIP85Mnemonic(test.lang, test.words, test.index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mnemonic != test.mnemonic {
+			t.Fatalf("%s %d words: expected %s, got %s", test.lang.Name(), test.words, test.mnemonic, mnemonic)
+		}
+		if !test.lang.IsMnemonicValid(mnemonic) {
+			t.Fatalf("%s %d words: derived mnemonic is invalid", test.lang.Name(), test.words)
+		}
+	}
+
+	hexes := []struct {
+		numBytes int
+		index    uint32
+		entropy  string
+	}{
+		{64, 0, "3c9a3668f66759fe4973bd457d0fb9bc916355f5560bc5cd642507d6c8b176b346e23a61de1023cf58323285a29d8093f046f0e520207dc76355379bbf029daf"},
+		{32, 1, "efd282b3288ae731f564af7c2f50e07f0975f87be1b4b5ea56dbed63b279a5c5"},
+	}
+	for _, test := range hexes {
+		entropy, err := master.BIP85Hex(test.numBytes, test.index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(entropy) != test.entropy {
+			t.Fatalf("hex %d bytes: expected %s, got %x", test.numBytes, test.entropy, entropy)
+		}
+	}
+
+	child, err := master.BIP85ExtendedKey(0, polisNetPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "pprv25JDnxDbrr7rvgVYwvLAx6hQmNrR1MqpPgjucpYennPnHJstMYaHtkFQksoojH2Ew7ppGxj6yoaVbfSSTJANtqNwc9qT3MDy5v1Q7uV3NW"
+	if child.String() != expected {
+		t.Fatalf("xprv: expected %s, got %s", expected, child.String())
+	}
+}
+
+func TestBIP85Entropy(t *testing.T) {
+	master := bip85Master(t)
+
+	// The entropy is the HMAC-SHA512 of the private key at the hardened
+	// path m/83696968'/128169'/32'/0'.
+	key := master
+	for _, i := range []uint32{hdwallets.BIP85Purpose, hdwallets.BIP85AppHex, 32, 0} {
+		var err error
+		key, err = key.Child(hdwallets.HardenedKeyStart + i)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	priv, err := key.BlsPrivKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	privBytes := priv.Serialize()
+	mac := hmac.New(sha512.New, []byte("bip-entropy-from-k"))
+	mac.Write(privBytes[:])
+
+	entropy, err := master.BIP85Entropy(hdwallets.BIP85AppHex, 32, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(entropy, mac.Sum(nil)) {
+		t.Fatal("entropy does not match HMAC-SHA512 of the derived key")
+	}
+
+	other, err := master.BIP85Entropy(hdwallets.BIP85AppHex, 32, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(entropy, other) {
+		t.Fatal("entropy at different indices must differ")
+	}
+
+	// The intermediate keys are not remembered by the master, even when it
+	// tracks its children.
+	master.SetTrackChildren(true)
+	for i := uint32(0); i < 10; i++ {
+		if _, err := master.BIP85Hex(16, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := master.NumChildren(); n != 0 {
+		t.Fatalf("expected no remembered children, got %d", n)
+	}
+}
+
+func TestBIP85Errors(t *testing.T) {
+	master := bip85Master(t)
+
+	if _, err := master.BIP85Mnemonic(bip39.English, 13, 0); err != hdwallets.ErrBIP85InvalidWordCount {
+		t.Fatalf("expected %v, got %v", hdwallets.ErrBIP85InvalidWordCount, err)
+	}
+	if _, err := master.BIP85Mnemonic(bip39.NewLanguage([]string{"custom"}), 12, 0); err != hdwallets.ErrBIP85UnknownLanguage {
+		t.Fatalf("expected %v, got %v", hdwallets.ErrBIP85UnknownLanguage, err)
+	}
+	if _, err := master.BIP85Hex(15, 0); err != hdwallets.ErrBIP85InvalidHexLen {
+		t.Fatalf("expected %v, got %v", hdwallets.ErrBIP85InvalidHexLen, err)
+	}
+	if _, err := master.BIP85Hex(65, 0); err != hdwallets.ErrBIP85InvalidHexLen {
+		t.Fatalf("expected %v, got %v", hdwallets.ErrBIP85InvalidHexLen, err)
+	}
+	if _, err := master.BIP85Entropy(hdwallets.HardenedKeyStart); err != hdwallets.ErrBIP85InvalidIndex {
+		t.Fatalf("expected %v, got %v", hdwallets.ErrBIP85InvalidIndex, err)
+	}
+
+	pub, err := master.Neuter(polisNetPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pub.BIP85Entropy(hdwallets.BIP85AppHex, 32, 0); err != hdwallets.ErrNotPrivExtKey {
+		t.Fatalf("expected %v, got %v", hdwallets.ErrNotPrivExtKey, err)
+	}
+}
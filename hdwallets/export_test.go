@@ -0,0 +1,8 @@
+package hdwallets
+
+// NumChildren returns the number of children remembered by the extended key.
+func (k *ExtendedKey) NumChildren() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.children)
+}
@@ -10,15 +10,19 @@ import (
 	"bytes"
 	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/sha512"
+	"encoding"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"hash"
 	"math/big"
+	"sync"
 
 	"github.com/grupokindynos/ogen-utils/base58"
 	"github.com/grupokindynos/ogen-utils/chainhash"
+	"github.com/grupokindynos/ogen-utils/secret"
 	"github.com/phoreproject/bls"
 	"github.com/phoreproject/bls/g1pubs"
 )
@@ -108,6 +112,29 @@ var (
 		"length is invalid")
 )
 
+// DerivationMode selects the hash function used with HMAC when deriving master
+// and child keys.
+type DerivationMode uint8
+
+const (
+	// DeriveHash512 derives keys using HMAC over Hash512.  This is the
+	// default mode and matches every key derived before modes existed.
+	DeriveHash512 DerivationMode = iota
+
+	// DeriveHMACSHA512 derives keys using HMAC-SHA512 as specified by
+	// [BIP32].
+	DeriveHMACSHA512
+)
+
+// newHMAC returns a new HMAC keyed with key using the hash function selected
+// by the derivation mode.
+func (m DerivationMode) newHMAC(key []byte) hash.Hash {
+	if m == DeriveHMACSHA512 {
+		return hmac.New(sha512.New, key)
+	}
+	return hmac.New(NewHash512, key)
+}
+
 // masterKey is the master key used along with a random seed used to generate
 // the master node in the hierarchical tree.
 var masterKey = []byte("BLS HD seed")
@@ -124,6 +151,20 @@ type ExtendedKey struct {
 	childNum  uint32
 	version   []byte
 	isPrivate bool
+	mode      DerivationMode
+
+	// secret holds the key and chain code once LockMemory has been
+	// called, in which case key and chainCode slice into it.
+	secret *secret.Buffer
+
+	// children are the keys derived from this one by Child so Destroy
+	// can clear them.  They are only tracked when the memory of this key
+	// is locked or trackChildren is set, and parent points back to the
+	// key a tracked child was derived from so Release can untrack it.
+	mu            sync.Mutex
+	children      []*ExtendedKey
+	trackChildren bool
+	parent        *ExtendedKey
 }
 
 // NewExtendedKey returns a new instance of an extended key with the given
@@ -165,8 +206,9 @@ func (k *ExtendedKey) pubKeyBytes() []byte {
 	if len(k.pubKey) == 0 {
 		var secretKey [32]byte
 		copy(secretKey[:], k.key)
-		secret := g1pubs.DeserializeSecretKey(secretKey)
-		public := g1pubs.PrivToPub(secret)
+		sk := g1pubs.DeserializeSecretKey(secretKey)
+		secret.Zero(secretKey[:])
+		public := g1pubs.PrivToPub(sk)
 		serialized := public.Serialize()
 		k.pubKey = serialized[:]
 	}
@@ -214,11 +256,44 @@ func (k *ExtendedKey) ParentFingerprint() uint32 {
 // knowledge of the parent private key) whereas hardened extended keys may not
 // be.
 //
+// When the memory of this extended key is locked (see LockMemory), the memory
+// of the child is locked as well, and the child is remembered by this extended
+// key so that Destroy can clear it.  Children of unlocked keys are only
+// remembered when requested with SetTrackChildren.  Use Release to clear a
+// remembered child and forget it, so long-lived keys deriving many children
+// don't keep them all.
+//
 // NOTE: There is an extremely small chance (< 1 in 2^127) the specific child
 // index does not derive to a usable child.  The ErrInvalidChild error will be
 // returned if this should occur, and the caller is expected to ignore the
 // invalid child and simply increment to the next index.
 func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
+	child, err := k.derive(i)
+	if err != nil {
+		return nil, err
+	}
+
+	if k.secret != nil {
+		if err := child.LockMemory(); err != nil {
+			child.Zero()
+			return nil, err
+		}
+	}
+
+	k.mu.Lock()
+	if k.secret != nil || k.trackChildren {
+		child.trackChildren = k.trackChildren
+		child.parent = k
+		k.children = append(k.children, child)
+	}
+	k.mu.Unlock()
+
+	return child, nil
+}
+
+// derive derives the child at index i like Child, without locking its memory
+// or remembering it.
+func (k *ExtendedKey) derive(i uint32) (*ExtendedKey, error) {
 	// Prevent derivation of children beyond the max allowed depth.
 	if k.depth == maxUint8 {
 		return nil, ErrDeriveBeyondMaxDepth
@@ -263,8 +338,10 @@ func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 		// starts with the secp256k1 compressed public key bytes.
 
 		// data in this case is 52 bytes
-		data = append(k.pubKeyBytes(), []byte{0, 0, 0, 0}...)
+		data = append([]byte{}, k.pubKeyBytes()...)
+		data = append(data, []byte{0, 0, 0, 0}...)
 	}
+	defer secret.Zero(data)
 
 	keyLen := len(data) - 4
 	binary.BigEndian.PutUint32(data[keyLen:], i)
@@ -273,16 +350,19 @@ func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 	// data:
 	//   I = HMAC-SHA512(Key = chainCode, Data = data)
 
-	hmac512 := hmac.New(NewHash512, k.chainCode)
+	hmac512 := k.mode.newHMAC(k.chainCode)
 	hmac512.Write(data)
 	ilr := hmac512.Sum(nil)
+	defer secret.Zero(ilr)
 
 	// Split "I" into two 32-byte sequences Il and Ir where:
 	//   Il = intermediate key used to derive the child
 	//   Ir = child chain code
 	var il [32]byte
 	copy(il[:], ilr[:len(ilr)/2])
-	childChainCode := ilr[len(ilr)/2:]
+	defer secret.Zero(il[:])
+	childChainCode := make([]byte, len(ilr)/2)
+	copy(childChainCode, ilr[len(ilr)/2:])
 
 	// Both derived public or private keys rely on treating the left 32-byte
 	// sequence calculated above (Il) as a 256-bit integer that is used to derive
@@ -310,6 +390,7 @@ func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 		var parentKey [32]byte
 		copy(parentKey[:], k.key)
 		parentSecret := g1pubs.DeserializeSecretKey(parentKey)
+		secret.Zero(parentKey[:])
 		parentFr := parentSecret.GetFRElement()
 
 		// childKey = parse256(Il) + parenKey
@@ -349,8 +430,10 @@ func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 	// The fingerprint of the parent for the derived child is the first 4
 	// bytes of the RIPEMD160(SHA256(parentPubKey)).
 	parentFP := chainhash.Hash160(k.pubKeyBytes())[:4]
-	return NewExtendedKey(k.version, childKey, childChainCode, parentFP,
-		k.depth+1, i, isPrivate), nil
+	child := NewExtendedKey(k.version, childKey, childChainCode, parentFP,
+		k.depth+1, i, isPrivate)
+	child.mode = k.mode
+	return child, nil
 }
 
 // Neuter returns a new extended public key from this extended private key.  The
@@ -374,8 +457,14 @@ func (k *ExtendedKey) Neuter(net *NetPrefix) (*ExtendedKey, error) {
 	// key will simply be the pubkey of the current extended private key.
 	//
 	// This is the function N((k,c)) -> (K, c) from [BIP32].
-	return NewExtendedKey(version, k.pubKeyBytes(), k.chainCode, k.parentFP,
-		k.depth, k.childNum, false), nil
+	//
+	// The fields are copied so clearing this extended key doesn't clear the
+	// returned extended public key.
+	pub := NewExtendedKey(version, append([]byte{}, k.pubKeyBytes()...),
+		append([]byte{}, k.chainCode...), append([]byte{}, k.parentFP...),
+		k.depth, k.childNum, false)
+	pub.mode = k.mode
+	return pub, nil
 }
 
 // BlsPubKey converts the extended key to a bls public key and returns it.
@@ -396,6 +485,7 @@ func (k *ExtendedKey) BlsPrivKey() (*g1pubs.SecretKey, error) {
 
 	var secretKeyBytes [32]byte
 	copy(secretKeyBytes[:], k.key)
+	defer secret.Zero(secretKeyBytes[:])
 
 	return g1pubs.DeserializeSecretKey(secretKeyBytes), nil
 }
@@ -456,29 +546,126 @@ func (k *ExtendedKey) SetNet(net *NetPrefix) {
 	}
 }
 
-// zero sets all bytes in the passed slice to zero.  This is used to
-// explicitly clear private key material from memory.
-func zero(b []byte) {
-	lenb := len(b)
-	for i := 0; i < lenb; i++ {
-		b[i] = 0
+// DerivationMode returns the mode used to derive children of the extended key.
+func (k *ExtendedKey) DerivationMode() DerivationMode {
+	return k.mode
+}
+
+// SetDerivationMode associates the extended key, and any child keys yet to be
+// derived from it, with the passed derivation mode.  The mode is not part of
+// the serialized key, so keys parsed with NewKeyFromString always start out
+// using DeriveHash512.
+func (k *ExtendedKey) SetDerivationMode(mode DerivationMode) {
+	k.mode = mode
+}
+
+// SetTrackChildren sets whether or not children derived by Child are
+// remembered so Destroy can clear them even when the memory of this extended
+// key isn't locked.  Children inherit the setting.
+func (k *ExtendedKey) SetTrackChildren(track bool) {
+	k.mu.Lock()
+	k.trackChildren = track
+	k.mu.Unlock()
+}
+
+// LockMemory moves the key and chain code to memory which is locked into RAM
+// so they can't be swapped to disk, and clears the previous copies.  Children
+// derived afterwards are locked and remembered as well.  The memory is
+// released by Zero, Destroy or Release, and otherwise only once the extended
+// key is garbage collected, so callers should call one of those as soon as the
+// key is no longer needed.
+//
+// Memory locking is only supported on Linux.  secret.ErrLockUnsupported is
+// returned on other platforms, in which case the extended key is unchanged.
+func (k *ExtendedKey) LockMemory() error {
+	if k.secret != nil {
+		return nil
+	}
+
+	keyLen := len(k.key)
+	buf := secret.NewBuffer(keyLen + len(k.chainCode))
+	copy(buf.Bytes(), k.key)
+	copy(buf.Bytes()[keyLen:], k.chainCode)
+	if err := buf.Lock(); err != nil {
+		buf.Destroy()
+		return err
 	}
+
+	secret.Zero(k.key)
+	secret.Zero(k.chainCode)
+	k.key = buf.Bytes()[:keyLen:keyLen]
+	k.chainCode = buf.Bytes()[keyLen:]
+	k.secret = buf
+	return nil
 }
 
 // Zero manually clears all fields and bytes in the extended key.  This can be
 // used to explicitly clear key material from memory for enhanced security
 // against memory scraping.  This function only clears this particular key and
-// not any children that have already been derived.
+// not any children that have already been derived.  Use Destroy to clear the
+// children as well.
 func (k *ExtendedKey) Zero() {
-	zero(k.key)
-	zero(k.pubKey)
-	zero(k.chainCode)
-	zero(k.parentFP)
+	secret.Zero(k.key)
+	secret.Zero(k.pubKey)
+	secret.Zero(k.chainCode)
+	secret.Zero(k.parentFP)
+	if k.secret != nil {
+		// The chain code lives in the locked memory which is about
+		// to be released, so it must not be referenced anymore.
+		k.secret.Destroy()
+		k.secret = nil
+		k.chainCode = nil
+	}
 	k.version = nil
 	k.key = nil
 	k.depth = 0
 	k.childNum = 0
 	k.isPrivate = false
+	k.mode = DeriveHash512
+}
+
+// Destroy clears the extended key along with every child derived from it by
+// Child and remembered, recursively.  Neither this extended key nor any of
+// those children may be used afterwards.
+//
+// Children are only remembered when the memory of the extended key is locked
+// (see LockMemory) or tracking was turned on with SetTrackChildren before they
+// were derived.  Otherwise Destroy clears this extended key alone, just like
+// Zero, and children derived from it must be cleared by the caller.
+func (k *ExtendedKey) Destroy() {
+	k.mu.Lock()
+	children := k.children
+	k.children = nil
+	k.mu.Unlock()
+
+	for _, child := range children {
+		child.Destroy()
+	}
+	k.Zero()
+}
+
+// Release destroys the extended key like Destroy, and removes it from the
+// children remembered by the key it was derived from.
+func (k *ExtendedKey) Release() {
+	k.mu.Lock()
+	parent := k.parent
+	k.parent = nil
+	k.mu.Unlock()
+
+	if parent != nil {
+		parent.mu.Lock()
+		for i, child := range parent.children {
+			if child == k {
+				last := len(parent.children) - 1
+				parent.children[i] = parent.children[last]
+				parent.children[last] = nil
+				parent.children = parent.children[:last]
+				break
+			}
+		}
+		parent.mu.Unlock()
+	}
+	k.Destroy()
 }
 
 // NewMaster creates a new master node for use in creating a hierarchical
@@ -490,27 +677,38 @@ func (k *ExtendedKey) Zero() {
 // returned if this should occur, so the caller must check for it and generate a
 // new seed accordingly.
 func NewMaster(seed []byte, net *NetPrefix) (*ExtendedKey, error) {
+	return NewMasterWithMode(seed, net, DeriveHash512)
+}
+
+// NewMasterWithMode creates a new master node like NewMaster, but derives the
+// master node and all of its children using the passed derivation mode.
+func NewMasterWithMode(seed []byte, net *NetPrefix, mode DerivationMode) (*ExtendedKey, error) {
 	// Per [BIP32], the seed must be in range [MinSeedBytes, MaxSeedBytes].
 	if len(seed) < MinSeedBytes || len(seed) > MaxSeedBytes {
 		return nil, ErrInvalidSeedLen
 	}
-	hmac512 := hmac.New(NewHash512, masterKey)
+	hmac512 := mode.newHMAC(masterKey)
 	hmac512.Write(seed)
 	lr := hmac512.Sum(nil)
+	defer secret.Zero(lr)
 	// Split "I" into two 32-byte sequences Il and Ir where:
 	//   Il = master secret key
 	//   Ir = master chain code
 	var secretKeyBytes [32]byte
 	copy(secretKeyBytes[:], lr[:len(lr)/2])
+	defer secret.Zero(secretKeyBytes[:])
 	secretKey := g1pubs.DeriveSecretKey(secretKeyBytes)
-	chainCode := lr[len(lr)/2:]
+	chainCode := make([]byte, len(lr)/2)
+	copy(chainCode, lr[len(lr)/2:])
 	// Ensure the key in usable.
 
 	secretKeySer := secretKey.Serialize()
 
 	parentFP := []byte{0x00, 0x00, 0x00, 0x00}
-	return NewExtendedKey(net.ExtPriv, secretKeySer[:], chainCode,
-		parentFP, 0, 0, true), nil
+	master := NewExtendedKey(net.ExtPriv, secretKeySer[:], chainCode,
+		parentFP, 0, 0, true)
+	master.mode = mode
+	return master, nil
 }
 
 // NewKeyFromString returns a new extended key instance from a base58-encoded
@@ -589,17 +787,37 @@ func GenerateSeed(length uint8) ([]byte, error) {
 }
 
 // Hash512 is a 512-bit hash based on two SHA256 hashes concatenated together.
+// The digest of data d is:
+//
+//	SHA256(d || 0x00) || SHA256(d || 0x01)
+//
+// Both halves share the prefix d, so only a single running SHA256 state is
+// kept while data is written.  Sum clones that state once per half and
+// appends the suffix byte to the clone, which leaves the running state
+// untouched and keeps memory usage constant regardless of how much data has
+// been written.
 type Hash512 struct {
-	currentData []byte
+	state hash.Hash
+}
+
+// digest returns the running SHA256 state, creating it if needed so the zero
+// value of Hash512 is ready to use.
+func (h *Hash512) digest() hash.Hash {
+	if h.state == nil {
+		h.state = sha256.New()
+	}
+	return h.state
 }
 
-// BlockSize gets the block size of the hash (which is the same
-// as SHA256).
+// BlockSize gets the block size of the hash.
+//
+// NOTE: This is the SHA512 block size rather than the SHA256 one.  HMAC pads
+// its key to the block size, so changing it would change every derived key.
 func (h *Hash512) BlockSize() int { return sha512.BlockSize }
 
 // Reset resets the hash to the default state.
 func (h *Hash512) Reset() {
-	h.currentData = nil
+	h.digest().Reset()
 }
 
 // Size gets the size of the hash in bytes.
@@ -608,22 +826,36 @@ func (h *Hash512) Size() int {
 }
 
 // Sum appends the checksum of the hash to the data provided and returns it.
+// It does not change the underlying hash state.
 func (h *Hash512) Sum(data []byte) []byte {
-	h0 := chainhash.HashB(append(h.currentData, 0))
-	h1 := chainhash.HashB(append(h.currentData, 1))
+	out := h.sumWithSuffix(data, 0)
+	return h.sumWithSuffix(out, 1)
+}
+
+// sumWithSuffix appends SHA256(written data || suffix) to data using a clone
+// of the running state.
+func (h *Hash512) sumWithSuffix(data []byte, suffix byte) []byte {
+	// The SHA256 implementation in the standard library always supports
+	// marshaling its state, so these errors can't happen in practice.
+	state, err := h.digest().(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	clone := sha256.New()
+	if err := clone.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+		panic(err)
+	}
 
-	out := append(data, h0...)
-	out = append(out, h1...)
-	return out
+	clone.Write([]byte{suffix})
+	return clone.Sum(data)
 }
 
 func (h *Hash512) Write(data []byte) (int, error) {
-	h.currentData = append(h.currentData, data...)
-	return len(data), nil
+	return h.digest().Write(data)
 }
 
 // NewHash512 creates a new 512-bit hash.
 func NewHash512() hash.Hash {
-	h := &Hash512{}
+	h := &Hash512{state: sha256.New()}
 	return h
 }
@@ -8,8 +8,8 @@ import (
 
 	"github.com/phoreproject/bls/g1pubs"
 
-	"github.com/grupokindynos/olympus-utils/chainhash"
-	"github.com/grupokindynos/olympus-utils/hdwallets"
+	"github.com/grupokindynos/ogen-utils/chainhash"
+	"github.com/grupokindynos/ogen-utils/hdwallets"
 )
 
 type XORShift struct {
@@ -287,3 +287,331 @@ func TestDeriveKey(t *testing.T) {
 		}
 	}
 }
+
+func TestDerivationIsStable(t *testing.T) {
+	x := NewXORShift(200)
+
+	var key [64]byte
+	x.Read(key[:])
+	esk, err := hdwallets.NewMaster(key[:], polisNetPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	child, err := esk.Child(10 + hdwallets.HardenedKeyStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grandchild, err := child.Child(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// These keys were derived before Hash512 was made streaming and must
+	// never change.
+	expectedMaster := "pprv25JDnxDbrr7rw9PijFonaEuR7kG7SBF6nc9cAgGqR5jYpHK6zLqNg5LjLDrojYMXknuinhuGJneAkZ6BdR1Wpe3756Stcb3bRHxPyvvBku"
+	expectedGrandchild := "pprv26QNxv5U7NgW4M1z1ezCK7BXswCVzYarQRsMXLLT4K4VXqLqNvfVRAtMdjbzRe4zwy7HPSGtUKvpKixQ1wuWUv1qqUYsHqR3chfB7DmCMA"
+
+	if esk.String() != expectedMaster {
+		t.Fatalf("expected master key %s, got %s", expectedMaster, esk.String())
+	}
+
+	if grandchild.String() != expectedGrandchild {
+		t.Fatalf("expected derived key %s, got %s", expectedGrandchild, grandchild.String())
+	}
+}
+
+func TestHash512(t *testing.T) {
+	x := NewXORShift(200)
+
+	data := make([]byte, 1000)
+	x.Read(data)
+
+	expected := append(chainhash.HashB(append(append([]byte{}, data...), 0)),
+		chainhash.HashB(append(append([]byte{}, data...), 1))...)
+
+	// Write the data in uneven chunks to make sure the running state
+	// matches hashing everything at once.
+	h := hdwallets.NewHash512()
+	for i := 0; i < len(data); i += 7 {
+		end := i + 7
+		if end > len(data) {
+			end = len(data)
+		}
+		h.Write(data[i:end])
+	}
+
+	if !bytes.Equal(h.Sum(nil), expected) {
+		t.Fatal("expected streaming hash to match SHA256(d || 0) || SHA256(d || 1)")
+	}
+
+	// Sum must not modify the hash state.
+	if !bytes.Equal(h.Sum(nil), expected) {
+		t.Fatal("expected repeated calls to Sum to return the same digest")
+	}
+
+	h.Reset()
+	h.Write(data)
+	if !bytes.Equal(h.Sum([]byte{0xff})[1:], expected) {
+		t.Fatal("expected hash to match after reset")
+	}
+}
+
+func TestDerivationMode(t *testing.T) {
+	x := NewXORShift(200)
+
+	var key [64]byte
+	x.Read(key[:])
+	esk, err := hdwallets.NewMasterWithMode(key[:], polisNetPrefix, hdwallets.DeriveHMACSHA512)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legacy, err := hdwallets.NewMaster(key[:], polisNetPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if esk.String() == legacy.String() {
+		t.Fatal("expected HMAC-SHA512 master key to differ from legacy master key")
+	}
+
+	child10, err := esk.Child(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if child10.DerivationMode() != hdwallets.DeriveHMACSHA512 {
+		t.Fatal("expected child to inherit derivation mode")
+	}
+
+	epk, err := esk.Neuter(polisNetPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	childXPub, err := epk.Child(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	childPub, err := childXPub.BlsPubKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	priv, err := child10.BlsPrivKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !g1pubs.PrivToPub(priv).Equals(*childPub) {
+		t.Fatalf("expected child priv key to match child pub key")
+	}
+
+	// The mode isn't serialized, so it has to be set again after parsing.
+	parsed, err := hdwallets.NewKeyFromString(esk.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed.SetDerivationMode(hdwallets.DeriveHMACSHA512)
+
+	parsedChild, err := parsed.Child(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsedChild.String() != child10.String() {
+		t.Fatal("expected child of parsed key to match original child")
+	}
+}
+
+func BenchmarkHash512(b *testing.B) {
+	data := make([]byte, 1024)
+	NewXORShift(200).Read(data)
+
+	b.SetBytes(int64(len(data)))
+	h := hdwallets.NewHash512()
+	for i := 0; i < b.N; i++ {
+		h.Write(data)
+	}
+	h.Sum(nil)
+}
+
+func BenchmarkHash512Sum(b *testing.B) {
+	data := make([]byte, 1<<20)
+	NewXORShift(200).Read(data)
+
+	h := hdwallets.NewHash512()
+	h.Write(data)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Sum(nil)
+	}
+}
+
+func BenchmarkChild(b *testing.B) {
+	for _, mode := range []hdwallets.DerivationMode{hdwallets.DeriveHash512, hdwallets.DeriveHMACSHA512} {
+		b.Run(fmt.Sprintf("mode=%d", mode), func(b *testing.B) {
+			var key [64]byte
+			NewXORShift(200).Read(key[:])
+			esk, err := hdwallets.NewMasterWithMode(key[:], polisNetPrefix, mode)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := esk.Child(uint32(i) + hdwallets.HardenedKeyStart); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestDestroy(t *testing.T) {
+	x := NewXORShift(200)
+
+	var key [64]byte
+	x.Read(key[:])
+	esk, err := hdwallets.NewMaster(key[:], polisNetPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	esk.SetTrackChildren(true)
+
+	child, err := esk.Child(10 + hdwallets.HardenedKeyStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	grandchild, err := child.Child(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	epk, err := esk.Neuter(polisNetPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	epkStr := epk.String()
+
+	esk.Destroy()
+
+	for _, k := range []*hdwallets.ExtendedKey{esk, child, grandchild} {
+		if k.String() != "zeroed extended key" {
+			t.Fatal("expected key and all derived children to be cleared")
+		}
+	}
+
+	// Neutered keys don't share memory with their private key.
+	if epk.String() != epkStr {
+		t.Fatal("expected neutered key to be unaffected by Destroy")
+	}
+}
+
+func TestChildTracking(t *testing.T) {
+	x := NewXORShift(200)
+
+	var key [64]byte
+	x.Read(key[:])
+	esk, err := hdwallets.NewMaster(key[:], polisNetPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Children of unlocked keys are not remembered by default.
+	for i := uint32(0); i < 10; i++ {
+		if _, err := esk.Child(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := esk.NumChildren(); n != 0 {
+		t.Fatalf("expected no remembered children, got %d", n)
+	}
+
+	esk.SetTrackChildren(true)
+	first, err := esk.Child(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := esk.Child(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grandchild, err := first.Child(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if esk.NumChildren() != 2 || first.NumChildren() != 1 {
+		t.Fatal("expected tracked children to be remembered")
+	}
+
+	// Releasing a child clears it and its children and forgets it.
+	eskStr := esk.String()
+	first.Release()
+	if esk.NumChildren() != 1 {
+		t.Fatalf("expected released child to be forgotten, got %d children", esk.NumChildren())
+	}
+	for _, k := range []*hdwallets.ExtendedKey{first, grandchild} {
+		if k.String() != "zeroed extended key" {
+			t.Fatal("expected released child and its children to be cleared")
+		}
+	}
+	if esk.String() != eskStr || second.String() == "zeroed extended key" {
+		t.Fatal("expected releasing a child to leave other keys untouched")
+	}
+
+	// Releasing a key without a parent destroys it.
+	esk.Release()
+	if esk.String() != "zeroed extended key" || second.String() != "zeroed extended key" {
+		t.Fatal("expected released key and its children to be cleared")
+	}
+}
+
+func TestLockMemory(t *testing.T) {
+	x := NewXORShift(200)
+
+	var key [64]byte
+	x.Read(key[:])
+	esk, err := hdwallets.NewMaster(key[:], polisNetPrefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unlockedChild, err := esk.Child(10 + hdwallets.HardenedKeyStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eskStr := esk.String()
+	if err := esk.LockMemory(); err != nil {
+		t.Skipf("unable to lock memory: %v", err)
+	}
+	defer esk.Destroy()
+
+	if esk.String() != eskStr {
+		t.Fatal("expected key to be unchanged after locking memory")
+	}
+
+	child, err := esk.Child(10 + hdwallets.HardenedKeyStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if child.String() != unlockedChild.String() {
+		t.Fatal("expected child of locked key to match child of unlocked key")
+	}
+
+	// Children of locked keys are remembered until released.
+	if n := esk.NumChildren(); n != 1 {
+		t.Fatalf("expected 1 remembered child, got %d", n)
+	}
+	child.Release()
+	if n := esk.NumChildren(); n != 0 {
+		t.Fatalf("expected released child to be forgotten, got %d children", n)
+	}
+}
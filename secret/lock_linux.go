@@ -0,0 +1,40 @@
+//go:build linux
+// +build linux
+
+package secret
+
+import (
+	"syscall"
+)
+
+// allocLocked returns size bytes of anonymous memory locked into RAM.  The
+// memory is mapped separately from the Go heap so the lock covers whole
+// pages which hold nothing but the secret.
+func allocLocked(size int) ([]byte, error) {
+	if size == 0 {
+		return []byte{}, nil
+	}
+
+	data, err := syscall.Mmap(-1, 0, size, syscall.PROT_READ|syscall.PROT_WRITE,
+		syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := syscall.Mlock(data); err != nil {
+		syscall.Munmap(data)
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// freeLocked unlocks and unmaps memory returned by allocLocked.
+func freeLocked(data []byte) {
+	if len(data) == 0 {
+		return
+	}
+
+	syscall.Munlock(data)
+	syscall.Munmap(data)
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+// +build !linux
+
+package secret
+
+// allocLocked always fails since memory locking is only implemented on Linux.
+func allocLocked(size int) ([]byte, error) {
+	return nil, ErrLockUnsupported
+}
+
+// freeLocked does nothing since allocLocked never succeeds.
+func freeLocked(data []byte) {}
@@ -0,0 +1,111 @@
+// Package secret provides helpers for handling sensitive byte slices such as
+// private keys, chain codes and seeds so they can be explicitly cleared from
+// memory once they are no longer needed.
+package secret
+
+import (
+	"errors"
+	"runtime"
+)
+
+// ErrLockUnsupported is returned when locking memory is not supported on the
+// current platform.
+var ErrLockUnsupported = errors.New("locking memory is not supported on this platform")
+
+// Zero sets all bytes in the passed slice to zero.  This is used to
+// explicitly clear secret material from memory.
+func Zero(b []byte) {
+	lenb := len(b)
+	for i := 0; i < lenb; i++ {
+		b[i] = 0
+	}
+}
+
+// Buffer houses secret bytes which are cleared when the buffer is destroyed.
+//
+// A buffer starts out on the Go heap.  Calling Lock moves its contents to
+// memory which is locked into RAM so it can't be swapped to disk.  Locked
+// memory should be released with Destroy as soon as the buffer is no longer
+// needed; otherwise it is only released once the buffer is garbage collected.
+type Buffer struct {
+	data   []byte
+	locked bool
+}
+
+// NewBuffer returns a new zero filled buffer of the given size.
+func NewBuffer(size int) *Buffer {
+	return &Buffer{data: make([]byte, size)}
+}
+
+// NewBufferFrom returns a new buffer holding a copy of b.  The passed slice is
+// cleared, so the returned buffer is the only copy of the secret.
+func NewBufferFrom(b []byte) *Buffer {
+	buf := NewBuffer(len(b))
+	copy(buf.data, b)
+	Zero(b)
+	return buf
+}
+
+// Bytes returns the secret bytes held by the buffer.  The returned slice
+// aliases the buffer and must not be used after Destroy is called.
+func (b *Buffer) Bytes() []byte {
+	if b == nil {
+		return nil
+	}
+	return b.data
+}
+
+// Len returns the number of secret bytes held by the buffer.
+func (b *Buffer) Len() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.data)
+}
+
+// IsLocked returns whether or not the buffer contents are locked into RAM.
+func (b *Buffer) IsLocked() bool {
+	return b != nil && b.locked
+}
+
+// Lock moves the buffer contents to dedicated pages which are locked into RAM
+// and clears the previous copy.  Calling Lock on a locked buffer does nothing.
+//
+// Slices previously returned by Bytes no longer alias the buffer after a
+// successful call, and slices returned afterwards must not be used once the
+// buffer is unreachable, since the locked memory is then released by a
+// finalizer.  ErrLockUnsupported is returned on platforms without
+// memory locking, in which case the buffer is left untouched.
+func (b *Buffer) Lock() error {
+	if b.locked {
+		return nil
+	}
+
+	data, err := allocLocked(len(b.data))
+	if err != nil {
+		return err
+	}
+	copy(data, b.data)
+	Zero(b.data)
+
+	b.data = data
+	b.locked = true
+	runtime.SetFinalizer(b, (*Buffer).Destroy)
+	return nil
+}
+
+// Destroy clears the buffer contents and releases any locked memory.  It is
+// safe to call Destroy more than once.
+func (b *Buffer) Destroy() {
+	if b == nil {
+		return
+	}
+
+	Zero(b.data)
+	if b.locked {
+		freeLocked(b.data)
+		b.locked = false
+		runtime.SetFinalizer(b, nil)
+	}
+	b.data = nil
+}
@@ -0,0 +1,141 @@
+package secret
+
+import (
+	"io/ioutil"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestZero(t *testing.T) {
+	b := []byte{1, 2, 3, 4}
+	Zero(b)
+	for i, v := range b {
+		if v != 0 {
+			t.Fatalf("expected byte %d to be cleared, got %d", i, v)
+		}
+	}
+}
+
+func TestNewBufferFrom(t *testing.T) {
+	src := []byte{1, 2, 3, 4}
+	buf := NewBufferFrom(src)
+
+	if buf.Len() != 4 {
+		t.Fatalf("expected buffer length 4, got %d", buf.Len())
+	}
+
+	for i, v := range buf.Bytes() {
+		if v != byte(i+1) {
+			t.Fatal("expected buffer to hold a copy of the source")
+		}
+	}
+
+	for _, v := range src {
+		if v != 0 {
+			t.Fatal("expected source slice to be cleared")
+		}
+	}
+
+	data := buf.Bytes()
+	buf.Destroy()
+	for _, v := range data {
+		if v != 0 {
+			t.Fatal("expected destroyed buffer to be cleared")
+		}
+	}
+
+	if buf.Len() != 0 || buf.Bytes() != nil {
+		t.Fatal("expected destroyed buffer to be empty")
+	}
+
+	// Destroying twice must be harmless.
+	buf.Destroy()
+}
+
+func TestBufferLock(t *testing.T) {
+	buf := NewBufferFrom([]byte{1, 2, 3, 4})
+	old := buf.Bytes()
+
+	err := buf.Lock()
+	if err == ErrLockUnsupported || err == syscall.EPERM || err == syscall.ENOMEM {
+		t.Skipf("unable to lock memory: %v", err)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer buf.Destroy()
+
+	if !buf.IsLocked() {
+		t.Fatal("expected buffer to be locked")
+	}
+
+	for _, v := range old {
+		if v != 0 {
+			t.Fatal("expected previous copy to be cleared after locking")
+		}
+	}
+
+	for i, v := range buf.Bytes() {
+		if v != byte(i+1) {
+			t.Fatal("expected locked buffer to keep its contents")
+		}
+	}
+
+	if err := buf.Lock(); err != nil {
+		t.Fatalf("expected locking a locked buffer to succeed, got %v", err)
+	}
+}
+
+// lockedKB returns the amount of memory locked by the process, in kB.
+func lockedKB(t *testing.T) int {
+	status, err := ioutil.ReadFile("/proc/self/status")
+	if err != nil {
+		t.Skipf("unable to read locked memory: %v", err)
+	}
+	for _, line := range strings.Split(string(status), "\n") {
+		if strings.HasPrefix(line, "VmLck:") {
+			kb, err := strconv.Atoi(strings.Fields(line)[1])
+			if err != nil {
+				t.Fatal(err)
+			}
+			return kb
+		}
+	}
+	t.Skip("unable to read locked memory")
+	return 0
+}
+
+func TestBufferFinalizer(t *testing.T) {
+	before := lockedKB(t)
+
+	lockBuffers := func() {
+		for i := 0; i < 16; i++ {
+			buf := NewBufferFrom([]byte{1, 2, 3, 4})
+			err := buf.Lock()
+			if err == ErrLockUnsupported || err == syscall.EPERM || err == syscall.ENOMEM {
+				t.Skipf("unable to lock memory: %v", err)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	lockBuffers()
+	if lockedKB(t) <= before {
+		t.Fatal("expected locked buffers to lock memory")
+	}
+
+	// The buffers are unreachable, so their memory is released once they
+	// are garbage collected.
+	for i := 0; i < 50 && lockedKB(t) > before; i++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := lockedKB(t); after > before {
+		t.Fatalf("expected unreachable buffers to release their memory, locked %d kB, want %d kB", after, before)
+	}
+}
@@ -0,0 +1,51 @@
+package slip39
+
+import (
+	"crypto/sha256"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// salt returns the Feistel round salt for the given identifier. Extendable
+// backups do not bind the salt to the identifier.
+func salt(identifier int, extendable bool) []byte {
+	if extendable {
+		return nil
+	}
+	return append(append([]byte(nil), customizationString...),
+		byte(identifier>>8), byte(identifier))
+}
+
+// roundFunction is the PBKDF2 based round function of the Feistel network.
+func roundFunction(i int, passphrase []byte, exponent int, salt, r []byte) []byte {
+	password := append([]byte{byte(i)}, passphrase...)
+	iterations := (baseIterationCount << uint(exponent)) / roundCount
+	return pbkdf2.Key(password, append(append([]byte(nil), salt...), r...), iterations, len(r), sha256.New)
+}
+
+// feistel runs the four round Feistel network over data in the given round
+// order.
+func feistel(data, passphrase []byte, exponent, identifier int, extendable bool, rounds []int) []byte {
+	half := len(data) / 2
+	l := append([]byte(nil), data[:half]...)
+	r := append([]byte(nil), data[half:]...)
+	s := salt(identifier, extendable)
+	for _, i := range rounds {
+		f := roundFunction(i, passphrase, exponent, s, r)
+		for j := range l {
+			l[j] ^= f[j]
+		}
+		l, r = r, l
+	}
+	return append(r, l...)
+}
+
+// encrypt encrypts the master secret with the passphrase.
+func encrypt(masterSecret, passphrase []byte, exponent, identifier int, extendable bool) []byte {
+	return feistel(masterSecret, passphrase, exponent, identifier, extendable, []int{0, 1, 2, 3})
+}
+
+// decrypt decrypts the encrypted master secret with the passphrase.
+func decrypt(encrypted, passphrase []byte, exponent, identifier int, extendable bool) []byte {
+	return feistel(encrypted, passphrase, exponent, identifier, extendable, []int{3, 2, 1, 0})
+}
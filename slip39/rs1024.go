@@ -0,0 +1,74 @@
+package slip39
+
+var (
+	// customizationString is mixed into the checksum of shares which do
+	// not have the extendable backup flag set.
+	customizationString = []byte("shamir")
+
+	// customizationStringExtendable is mixed into the checksum of shares
+	// which have the extendable backup flag set.
+	customizationStringExtendable = []byte("shamir_extendable")
+
+	// rs1024Generator holds the generator coefficients of the Reed-Solomon
+	// code over GF(1024) used for the share checksum.
+	rs1024Generator = [10]uint32{
+		0xe0e040,
+		0x1c1c080,
+		0x3838100,
+		0x7070200,
+		0xe0e0009,
+		0x1c0c2412,
+		0x38086c24,
+		0x3090fc48,
+		0x21b1f890,
+		0x3f3f120,
+	}
+)
+
+// customization returns the checksum customization string for the given
+// extendable backup flag.
+func customization(extendable bool) []byte {
+	if extendable {
+		return customizationStringExtendable
+	}
+	return customizationString
+}
+
+// rs1024Polymod computes the RS1024 remainder of the customization string
+// followed by the given 10-bit values.
+func rs1024Polymod(cs []byte, values []int) uint32 {
+	chk := uint32(1)
+	step := func(v uint32) {
+		b := chk >> 20
+		chk = (chk&0xfffff)<<10 ^ v
+		for i := uint(0); i < 10; i++ {
+			if (b>>i)&1 != 0 {
+				chk ^= rs1024Generator[i]
+			}
+		}
+	}
+	for _, c := range cs {
+		step(uint32(c))
+	}
+	for _, v := range values {
+		step(uint32(v))
+	}
+	return chk
+}
+
+// rs1024CreateChecksum returns the three checksum words for the given data.
+func rs1024CreateChecksum(data []int, extendable bool) []int {
+	values := make([]int, len(data)+checksumLengthWords)
+	copy(values, data)
+	polymod := rs1024Polymod(customization(extendable), values) ^ 1
+	checksum := make([]int, checksumLengthWords)
+	for i := range checksum {
+		checksum[i] = int(polymod>>(radixBits*uint(checksumLengthWords-1-i))) & 1023
+	}
+	return checksum
+}
+
+// rs1024VerifyChecksum reports whether data ends with a valid checksum.
+func rs1024VerifyChecksum(data []int, extendable bool) bool {
+	return rs1024Polymod(customization(extendable), data) == 1
+}
@@ -0,0 +1,156 @@
+package slip39
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+)
+
+// expTable and logTable hold the exponentials and logarithms of GF(256)
+// with the Rijndael polynomial x^8 + x^4 + x^3 + x + 1 and generator x + 1.
+var expTable, logTable = precomputeExpLog()
+
+func precomputeExpLog() (exp [255]byte, log [256]byte) {
+	poly := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(poly)
+		log[poly] = byte(i)
+		// Multiply poly by the polynomial x + 1.
+		poly = (poly << 1) ^ poly
+		// Reduce poly by x^8 + x^4 + x^3 + x + 1.
+		if poly&0x100 != 0 {
+			poly ^= 0x11b
+		}
+	}
+	return exp, log
+}
+
+// rawShare is a single point of a shared polynomial.
+type rawShare struct {
+	x    byte
+	data []byte
+}
+
+// interpolate evaluates at x the polynomial which passes through all the
+// given shares.
+func interpolate(shares []rawShare, x byte) ([]byte, error) {
+	seen := make(map[byte]bool, len(shares))
+	for _, s := range shares {
+		if seen[s.x] {
+			return nil, ErrDuplicateShare
+		}
+		seen[s.x] = true
+		if len(s.data) != len(shares[0].data) {
+			return nil, ErrShareLengthMismatch
+		}
+	}
+
+	for _, s := range shares {
+		if s.x == x {
+			return append([]byte(nil), s.data...), nil
+		}
+	}
+
+	// Logarithm of the product of (x_i - x) for all i.
+	logProd := 0
+	for _, s := range shares {
+		logProd += int(logTable[s.x^x])
+	}
+
+	result := make([]byte, len(shares[0].data))
+	for _, s := range shares {
+		// Logarithm of the Lagrange basis polynomial evaluated at x.
+		logBasis := logProd - int(logTable[s.x^x])
+		for _, o := range shares {
+			logBasis -= int(logTable[s.x^o.x])
+		}
+		logBasis = ((logBasis % 255) + 255) % 255
+
+		for i, v := range s.data {
+			if v != 0 {
+				result[i] ^= expTable[(int(logTable[v])+logBasis)%255]
+			}
+		}
+	}
+	return result, nil
+}
+
+// createDigest returns the first bytes of HMAC-SHA256(random, secret).
+func createDigest(random, secret []byte) []byte {
+	mac := hmac.New(sha256.New, random)
+	mac.Write(secret)
+	return mac.Sum(nil)[:digestLength]
+}
+
+// splitSecret splits secret into shareCount shares of which any threshold
+// are enough to recover it.
+func splitSecret(threshold, shareCount int, secret []byte) ([]rawShare, error) {
+	if threshold < 1 {
+		return nil, ErrInvalidThreshold
+	}
+	if threshold > shareCount {
+		return nil, ErrThresholdExceedsCount
+	}
+	if shareCount > maxShareCount {
+		return nil, ErrTooManyShares
+	}
+
+	shares := make([]rawShare, 0, shareCount)
+
+	// With a threshold of one every share is the secret itself.
+	if threshold == 1 {
+		for i := 0; i < shareCount; i++ {
+			shares = append(shares, rawShare{x: byte(i), data: append([]byte(nil), secret...)})
+		}
+		return shares, nil
+	}
+
+	randomSharesCount := threshold - 2
+	for i := 0; i < randomSharesCount; i++ {
+		data := make([]byte, len(secret))
+		if _, err := rand.Read(data); err != nil {
+			return nil, err
+		}
+		shares = append(shares, rawShare{x: byte(i), data: data})
+	}
+
+	randomPart := make([]byte, len(secret)-digestLength)
+	if _, err := rand.Read(randomPart); err != nil {
+		return nil, err
+	}
+	digest := append(createDigest(randomPart, secret), randomPart...)
+
+	base := append(append([]rawShare(nil), shares...),
+		rawShare{x: digestIndex, data: digest},
+		rawShare{x: secretIndex, data: secret},
+	)
+	for i := randomSharesCount; i < shareCount; i++ {
+		data, err := interpolate(base, byte(i))
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, rawShare{x: byte(i), data: data})
+	}
+	return shares, nil
+}
+
+// recoverSecret recovers the secret shared with the given threshold and
+// verifies it against the embedded digest.
+func recoverSecret(threshold int, shares []rawShare) ([]byte, error) {
+	if threshold == 1 {
+		return append([]byte(nil), shares[0].data...), nil
+	}
+
+	secret, err := interpolate(shares, secretIndex)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := interpolate(shares, digestIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(digest[:digestLength], createDigest(digest[digestLength:], secret)) {
+		return nil, ErrInvalidDigest
+	}
+	return secret, nil
+}
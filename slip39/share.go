@@ -0,0 +1,159 @@
+package slip39
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// wordIndex maps every word of WordList to its index.
+var wordIndex = func() map[string]int {
+	m := make(map[string]int, len(WordList))
+	for i, w := range WordList {
+		m[w] = i
+	}
+	return m
+}()
+
+// Share is a single SLIP-39 share as encoded in one mnemonic.
+type Share struct {
+	// Identifier is the random 15 bit identifier common to all the shares
+	// of one master secret.
+	Identifier int
+	// Extendable reports whether the shares were created as an extendable
+	// backup.
+	Extendable bool
+	// IterationExponent sets the PBKDF2 iteration count of the passphrase
+	// encryption to 10000 << IterationExponent.
+	IterationExponent int
+	// GroupIndex is the x coordinate of the group this share belongs to.
+	GroupIndex int
+	// GroupThreshold is the number of groups required to recover the
+	// master secret.
+	GroupThreshold int
+	// GroupCount is the total number of groups.
+	GroupCount int
+	// MemberIndex is the x coordinate of this share within its group.
+	MemberIndex int
+	// MemberThreshold is the number of shares required to recover the
+	// group secret.
+	MemberThreshold int
+	// Value is the share value.
+	Value []byte
+}
+
+// commonParametersMatch reports whether two shares belong to the same
+// master secret.
+func (s *Share) commonParametersMatch(o *Share) bool {
+	return s.Identifier == o.Identifier &&
+		s.Extendable == o.Extendable &&
+		s.IterationExponent == o.IterationExponent &&
+		s.GroupThreshold == o.GroupThreshold &&
+		s.GroupCount == o.GroupCount
+}
+
+// words returns the word indices encoding the share, including the
+// checksum.
+func (s *Share) words() []int {
+	ext := 0
+	if s.Extendable {
+		ext = 1
+	}
+	idExp := s.Identifier<<(iterationExpLengthBits+1) |
+		ext<<iterationExpLengthBits | s.IterationExponent
+	params := s.GroupIndex<<16 | (s.GroupThreshold-1)<<12 |
+		(s.GroupCount-1)<<8 | s.MemberIndex<<4 | (s.MemberThreshold - 1)
+
+	valueWords := (len(s.Value)*8 + radixBits - 1) / radixBits
+	data := []int{
+		idExp >> radixBits, idExp & 1023,
+		params >> radixBits, params & 1023,
+	}
+	data = append(data, intToIndices(new(big.Int).SetBytes(s.Value), valueWords)...)
+	return append(data, rs1024CreateChecksum(data, s.Extendable)...)
+}
+
+// Mnemonic returns the mnemonic encoding of the share.
+func (s *Share) Mnemonic() string {
+	indices := s.words()
+	words := make([]string, len(indices))
+	for i, idx := range indices {
+		words[i] = WordList[idx]
+	}
+	return strings.Join(words, " ")
+}
+
+// ParseShare decodes a share mnemonic and verifies its checksum.
+func ParseShare(mnemonic string) (*Share, error) {
+	fields := strings.Fields(strings.ToLower(mnemonic))
+	if len(fields) < minMnemonicLengthWords {
+		return nil, ErrInvalidMnemonicLength
+	}
+
+	data := make([]int, len(fields))
+	for i, w := range fields {
+		idx, ok := wordIndex[w]
+		if !ok {
+			return nil, fmt.Errorf("word %d `%v`: %w", i+1, w, ErrUnknownWord)
+		}
+		data[i] = idx
+	}
+
+	paddingLen := (radixBits * (len(data) - metadataLengthWords)) % 16
+	if paddingLen > 8 {
+		return nil, ErrInvalidMnemonicLength
+	}
+
+	idExp := data[0]<<radixBits | data[1]
+	s := &Share{
+		Identifier:        idExp >> (iterationExpLengthBits + 1),
+		Extendable:        (idExp>>iterationExpLengthBits)&1 == 1,
+		IterationExponent: idExp & (1<<iterationExpLengthBits - 1),
+	}
+	if !rs1024VerifyChecksum(data, s.Extendable) {
+		return nil, ErrInvalidChecksum
+	}
+
+	params := data[2]<<radixBits | data[3]
+	s.GroupIndex = params >> 16
+	s.GroupThreshold = (params>>12)&15 + 1
+	s.GroupCount = (params>>8)&15 + 1
+	s.MemberIndex = (params >> 4) & 15
+	s.MemberThreshold = params&15 + 1
+	if s.GroupCount < s.GroupThreshold {
+		return nil, ErrThresholdExceedsCount
+	}
+
+	valueData := data[idExpLengthWords+2 : len(data)-checksumLengthWords]
+	valueByteCount := (radixBits*len(valueData) - paddingLen) / 8
+	value := indicesToInt(valueData)
+	if value.BitLen() > valueByteCount*8 {
+		return nil, ErrInvalidPadding
+	}
+	s.Value = make([]byte, valueByteCount)
+	b := value.Bytes()
+	copy(s.Value[valueByteCount-len(b):], b)
+	return s, nil
+}
+
+// intToIndices splits v into count big-endian 10-bit word indices.
+func intToIndices(v *big.Int, count int) []int {
+	indices := make([]int, count)
+	mask := big.NewInt(1023)
+	t := new(big.Int).Set(v)
+	for i := count - 1; i >= 0; i-- {
+		indices[i] = int(new(big.Int).And(t, mask).Int64())
+		t.Rsh(t, radixBits)
+	}
+	return indices
+}
+
+// indicesToInt joins big-endian 10-bit word indices into an integer.
+func indicesToInt(indices []int) *big.Int {
+	v := new(big.Int)
+	for _, idx := range indices {
+		v.Lsh(v, radixBits)
+		v.Or(v, big.NewInt(int64(idx)))
+	}
+	return v
+}
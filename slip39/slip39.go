@@ -0,0 +1,295 @@
+// Package slip39 implements SLIP-0039, Shamir's secret-sharing for mnemonic
+// codes. A master secret is split into groups of share mnemonics so that it
+// can be recovered from a threshold of shares in a threshold of groups. The
+// recovered master secret can be used as the seed of hdwallets.NewMaster.
+package slip39
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	// radixBits is the number of bits encoded by one word.
+	radixBits = 10
+
+	// idLengthBits is the length of the random identifier in bits.
+	idLengthBits = 15
+
+	// iterationExpLengthBits is the length of the iteration exponent in
+	// bits.
+	iterationExpLengthBits = 4
+
+	// idExpLengthWords is the number of words holding the identifier,
+	// the extendable flag and the iteration exponent.
+	idExpLengthWords = 2
+
+	// checksumLengthWords is the number of words of the RS1024 checksum.
+	checksumLengthWords = 3
+
+	// metadataLengthWords is the number of words of a share which do not
+	// encode the share value.
+	metadataLengthWords = idExpLengthWords + 2 + checksumLengthWords
+
+	// minStrengthBits is the minimum allowed length of the master secret.
+	minStrengthBits = 128
+
+	// minMnemonicLengthWords is the length of the shortest valid share.
+	minMnemonicLengthWords = metadataLengthWords + (minStrengthBits+radixBits-1)/radixBits
+
+	// maxShareCount is the maximum number of shares or groups.
+	maxShareCount = 16
+
+	// digestLength is the length of the share digest in bytes.
+	digestLength = 4
+
+	// digestIndex is the x coordinate of the digest share.
+	digestIndex = 254
+
+	// secretIndex is the x coordinate of the shared secret.
+	secretIndex = 255
+
+	// baseIterationCount is the PBKDF2 iteration count for an iteration
+	// exponent of zero.
+	baseIterationCount = 10000
+
+	// roundCount is the number of rounds of the Feistel network.
+	roundCount = 4
+)
+
+var (
+	// ErrInvalidMnemonicLength is returned when a share mnemonic has an
+	// invalid number of words.
+	ErrInvalidMnemonicLength = errors.New("invalid share mnemonic length")
+
+	// ErrUnknownWord is returned when a share mnemonic contains a word which
+	// is not in the word list.
+	ErrUnknownWord = errors.New("word not in the SLIP-39 word list")
+
+	// ErrInvalidChecksum is returned when a share mnemonic fails its RS1024
+	// checksum.
+	ErrInvalidChecksum = errors.New("invalid share mnemonic checksum")
+
+	// ErrInvalidPadding is returned when the padding bits of a share value
+	// are not zero.
+	ErrInvalidPadding = errors.New("invalid share mnemonic padding")
+
+	// ErrInvalidThreshold is returned when a threshold is lower than one.
+	ErrInvalidThreshold = errors.New("threshold must be at least 1")
+
+	// ErrThresholdExceedsCount is returned when a threshold is greater than
+	// the number of shares or groups.
+	ErrThresholdExceedsCount = errors.New("threshold must not exceed the share count")
+
+	// ErrTooManyShares is returned when more than 16 shares or groups are
+	// requested.
+	ErrTooManyShares = errors.New("share count must not exceed 16")
+
+	// ErrMultipleSharesThresholdOne is returned when a group is configured
+	// with several members and a member threshold of one.
+	ErrMultipleSharesThresholdOne = errors.New("multiple member shares with member threshold 1 are not allowed, use 1-of-1 instead")
+
+	// ErrInvalidMasterSecret is returned when the master secret is shorter
+	// than 128 bits or has an odd length.
+	ErrInvalidMasterSecret = errors.New("master secret must be at least 128 bits and an even number of bytes")
+
+	// ErrInvalidPassphrase is returned when the passphrase contains
+	// characters other than printable ASCII.
+	ErrInvalidPassphrase = errors.New("passphrase must contain only printable ASCII characters")
+
+	// ErrInvalidIterationExponent is returned when the iteration exponent
+	// does not fit in four bits.
+	ErrInvalidIterationExponent = errors.New("iteration exponent must be between 0 and 15")
+
+	// ErrNoShares is returned when no share mnemonics are given.
+	ErrNoShares = errors.New("no share mnemonics given")
+
+	// ErrSharesMismatch is returned when the given shares do not belong to
+	// the same master secret.
+	ErrSharesMismatch = errors.New("share mnemonics do not belong to the same secret")
+
+	// ErrDuplicateShare is returned when a share index is repeated.
+	ErrDuplicateShare = errors.New("share indices must be unique")
+
+	// ErrShareLengthMismatch is returned when share values differ in
+	// length.
+	ErrShareLengthMismatch = errors.New("share values must all have the same length")
+
+	// ErrNotEnoughGroups is returned when fewer groups than the group
+	// threshold are complete.
+	ErrNotEnoughGroups = errors.New("not enough groups to recover the master secret")
+
+	// ErrNotEnoughShares is returned when a group has fewer shares than its
+	// member threshold.
+	ErrNotEnoughShares = errors.New("not enough shares to recover the group secret")
+
+	// ErrInvalidDigest is returned when the recovered secret does not match
+	// the digest embedded in the shares.
+	ErrInvalidDigest = errors.New("invalid digest of the shared secret")
+)
+
+// Group describes a group of member shares.
+type Group struct {
+	// MemberThreshold is the number of shares required to recover the
+	// group secret.
+	MemberThreshold int
+	// MemberCount is the number of shares created for the group.
+	MemberCount int
+}
+
+// validatePassphrase checks the passphrase holds only printable ASCII.
+func validatePassphrase(passphrase []byte) error {
+	for _, c := range passphrase {
+		if c < 32 || c > 126 {
+			return ErrInvalidPassphrase
+		}
+	}
+	return nil
+}
+
+// SplitMasterSecret splits the master secret into share mnemonics, one slice
+// per group. The master secret is recovered by CombineMnemonics from
+// memberThreshold shares of groupThreshold groups and the passphrase.
+func SplitMasterSecret(groupThreshold int, groups []Group, masterSecret, passphrase []byte, extendable bool, iterationExponent int) ([][]string, error) {
+	shares, err := splitMasterSecret(groupThreshold, groups, masterSecret, passphrase, extendable, iterationExponent)
+	if err != nil {
+		return nil, err
+	}
+	mnemonics := make([][]string, len(shares))
+	for i, group := range shares {
+		for _, s := range group {
+			mnemonics[i] = append(mnemonics[i], s.Mnemonic())
+		}
+	}
+	return mnemonics, nil
+}
+
+func splitMasterSecret(groupThreshold int, groups []Group, masterSecret, passphrase []byte, extendable bool, iterationExponent int) ([][]*Share, error) {
+	if len(masterSecret)*8 < minStrengthBits || len(masterSecret)%2 != 0 {
+		return nil, ErrInvalidMasterSecret
+	}
+	if err := validatePassphrase(passphrase); err != nil {
+		return nil, err
+	}
+	if iterationExponent < 0 || iterationExponent >= 1<<iterationExpLengthBits {
+		return nil, ErrInvalidIterationExponent
+	}
+	if groupThreshold > len(groups) {
+		return nil, ErrThresholdExceedsCount
+	}
+	for _, g := range groups {
+		if g.MemberThreshold == 1 && g.MemberCount > 1 {
+			return nil, ErrMultipleSharesThresholdOne
+		}
+	}
+
+	var id [2]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	identifier := int(binary.BigEndian.Uint16(id[:])) & (1<<idLengthBits - 1)
+
+	encrypted := encrypt(masterSecret, passphrase, iterationExponent, identifier, extendable)
+	groupShares, err := splitSecret(groupThreshold, len(groups), encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([][]*Share, len(groups))
+	for i, g := range groups {
+		memberShares, err := splitSecret(g.MemberThreshold, g.MemberCount, groupShares[i].data)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range memberShares {
+			shares[i] = append(shares[i], &Share{
+				Identifier:        identifier,
+				Extendable:        extendable,
+				IterationExponent: iterationExponent,
+				GroupIndex:        int(groupShares[i].x),
+				GroupThreshold:    groupThreshold,
+				GroupCount:        len(groups),
+				MemberIndex:       int(m.x),
+				MemberThreshold:   g.MemberThreshold,
+				Value:             m.data,
+			})
+		}
+	}
+	return shares, nil
+}
+
+// CombineMnemonics recovers the master secret from the share mnemonics and
+// the passphrase used to create them.
+func CombineMnemonics(mnemonics []string, passphrase []byte) ([]byte, error) {
+	if len(mnemonics) == 0 {
+		return nil, ErrNoShares
+	}
+	if err := validatePassphrase(passphrase); err != nil {
+		return nil, err
+	}
+
+	shares := make([]*Share, len(mnemonics))
+	for i, m := range mnemonics {
+		s, err := ParseShare(m)
+		if err != nil {
+			return nil, err
+		}
+		shares[i] = s
+	}
+	return combineShares(shares, passphrase)
+}
+
+func combineShares(shares []*Share, passphrase []byte) ([]byte, error) {
+	first := shares[0]
+	groups := make(map[int][]*Share)
+	var order []int
+	for _, s := range shares {
+		if !s.commonParametersMatch(first) {
+			return nil, ErrSharesMismatch
+		}
+		if _, ok := groups[s.GroupIndex]; !ok {
+			order = append(order, s.GroupIndex)
+		} else if groups[s.GroupIndex][0].MemberThreshold != s.MemberThreshold {
+			return nil, ErrSharesMismatch
+		}
+		groups[s.GroupIndex] = append(groups[s.GroupIndex], s)
+	}
+	if len(groups) < first.GroupThreshold {
+		return nil, ErrNotEnoughGroups
+	}
+
+	groupShares := make([]rawShare, 0, first.GroupThreshold)
+	incomplete := false
+	for _, idx := range order {
+		members := groups[idx]
+		if len(members) < members[0].MemberThreshold {
+			incomplete = true
+			continue
+		}
+		raw := make([]rawShare, 0, members[0].MemberThreshold)
+		for _, m := range members[:members[0].MemberThreshold] {
+			raw = append(raw, rawShare{x: byte(m.MemberIndex), data: m.Value})
+		}
+		secret, err := recoverSecret(members[0].MemberThreshold, raw)
+		if err != nil {
+			return nil, err
+		}
+		groupShares = append(groupShares, rawShare{x: byte(idx), data: secret})
+		if len(groupShares) == first.GroupThreshold {
+			break
+		}
+	}
+	if len(groupShares) < first.GroupThreshold {
+		if incomplete {
+			return nil, ErrNotEnoughShares
+		}
+		return nil, ErrNotEnoughGroups
+	}
+
+	encrypted, err := recoverSecret(first.GroupThreshold, groupShares)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(encrypted, passphrase, first.IterationExponent, first.Identifier, first.Extendable), nil
+}
@@ -0,0 +1,357 @@
+package slip39
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/grupokindynos/ogen-utils/hdwallets"
+)
+
+// vectors are a subset of the official SLIP-39 test vectors, all of which use
+// the passphrase "TREZOR".  The group sharing vectors of the official
+// vectors.json are not included yet and must be copied verbatim from upstream.
+var vectors = []struct {
+	name      string
+	mnemonics []string
+	secret    string
+	err       error
+}{
+	{
+		name: "valid mnemonic without sharing (128 bits)",
+		mnemonics: []string{
+			"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboard",
+		},
+		secret: "bb54aac4b89dc868ba37d9cc21b2cece",
+	},
+	{
+		name: "mnemonic with invalid checksum (128 bits)",
+		mnemonics: []string{
+			"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision kidney",
+		},
+		err: ErrInvalidChecksum,
+	},
+	{
+		name: "mnemonic with invalid padding (128 bits)",
+		mnemonics: []string{
+			"duckling enlarge academic academic email result length solution fridge kidney coal piece deal husband erode duke ajar music cargo fitness",
+		},
+		err: ErrInvalidPadding,
+	},
+	{
+		name: "basic sharing 2-of-3 (128 bits)",
+		mnemonics: []string{
+			"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed",
+			"shadow pistol academic acid actress prayer class unknown daughter sweater depict flip twice unkind craft early superior advocate guest smoking",
+		},
+		secret: "b43ceb7e57a0ea8766221624d01b0864",
+	},
+	{
+		name: "basic sharing 2-of-3 with one share (128 bits)",
+		mnemonics: []string{
+			"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed",
+		},
+		err: ErrNotEnoughShares,
+	},
+	{
+		name: "valid mnemonic without sharing (256 bits)",
+		mnemonics: []string{
+			"theory painting academic academic armed sweater year military elder discuss acne wildlife boring employer fused large satoshi bundle carbon diagnose anatomy hamster leaves tracks paces beyond phantom capital marvel lips brave detect luck",
+		},
+		secret: "989baf9dcaad5b10ca33dfd8cc75e42477025dce88ae83e75a230086a0e00e92",
+	},
+	{
+		name: "valid extendable mnemonic without sharing (128 bits)",
+		mnemonics: []string{
+			"testify swimming academic academic column loyalty smear include exotic bedroom exotic wrist lobe cover grief golden smart junior estimate learn",
+		},
+		secret: "1679b4516e0ee5954351d288a838f45e",
+	},
+}
+
+func TestVectors(t *testing.T) {
+	for _, test := range vectors {
+		secret, err := CombineMnemonics(test.mnemonics, []byte("TREZOR"))
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error %v", test.name, err)
+			continue
+		}
+		if hex.EncodeToString(secret) != test.secret {
+			t.Errorf("%v: expected secret %v, got %x", test.name, test.secret, secret)
+		}
+	}
+}
+
+func TestWordList(t *testing.T) {
+	if len(WordList) != 1024 {
+		t.Fatalf("expected 1024 words, got %d", len(WordList))
+	}
+	prefixes := make(map[string]bool, len(WordList))
+	for i, w := range WordList {
+		if i > 0 && WordList[i-1] >= w {
+			t.Errorf("word list not sorted at %v", w)
+		}
+		p := w
+		if len(p) > 4 {
+			p = p[:4]
+		}
+		if prefixes[p] {
+			t.Errorf("prefix %v is not unique", p)
+		}
+		prefixes[p] = true
+	}
+}
+
+func TestShareRoundTrip(t *testing.T) {
+	for _, test := range vectors {
+		if test.err != nil {
+			continue
+		}
+		for _, m := range test.mnemonics {
+			s, err := ParseShare(m)
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", test.name, err)
+				continue
+			}
+			if s.Mnemonic() != m {
+				t.Errorf("%v: expected mnemonic %v, got %v", test.name, m, s.Mnemonic())
+			}
+		}
+	}
+}
+
+func TestSplitAndCombine(t *testing.T) {
+	masterSecret := []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ012345")
+	passphrase := []byte("TREZOR")
+
+	groups := []Group{{1, 1}, {2, 3}, {3, 5}}
+	for _, extendable := range []bool{false, true} {
+		mnemonics, err := SplitMasterSecret(2, groups, masterSecret, passphrase, extendable, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mnemonics) != len(groups) {
+			t.Fatalf("expected %d groups, got %d", len(groups), len(mnemonics))
+		}
+
+		combinations := [][]string{
+			{mnemonics[0][0], mnemonics[1][0], mnemonics[1][2]},
+			{mnemonics[1][1], mnemonics[1][2], mnemonics[2][4], mnemonics[2][0], mnemonics[2][2]},
+			{mnemonics[2][3], mnemonics[0][0], mnemonics[2][1], mnemonics[2][0]},
+		}
+		for _, c := range combinations {
+			secret, err := CombineMnemonics(c, passphrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(secret, masterSecret) {
+				t.Errorf("expected secret %x, got %x", masterSecret, secret)
+			}
+		}
+
+		secret, err := CombineMnemonics([]string{mnemonics[0][0], mnemonics[1][0], mnemonics[1][2]}, []byte("wrong"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(secret, masterSecret) {
+			t.Error("expected a different secret for a different passphrase")
+		}
+
+		_, err = CombineMnemonics([]string{mnemonics[0][0], mnemonics[1][0]}, passphrase)
+		if err != ErrNotEnoughShares {
+			t.Errorf("expected %v, got %v", ErrNotEnoughShares, err)
+		}
+		_, err = CombineMnemonics([]string{mnemonics[2][0], mnemonics[2][1], mnemonics[2][2]}, passphrase)
+		if err != ErrNotEnoughGroups {
+			t.Errorf("expected %v, got %v", ErrNotEnoughGroups, err)
+		}
+	}
+}
+
+// TestCombineGroupErrors tests the rejection of group shares which don't belong
+// together, the cases covered by the invalid group sharing vectors of SLIP-39.
+// The shares are split by this package and then altered, so they are not the
+// official vectors.
+func TestCombineGroupErrors(t *testing.T) {
+	passphrase := []byte("TREZOR")
+	mnemonics, err := SplitMasterSecret(2, []Group{{1, 1}, {2, 3}, {3, 5}}, []byte("ABCDEFGHIJKLMNOP"), passphrase, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// alter returns the mnemonic of group g and member m with a
+	// modification of its share, encoded with a valid checksum.
+	alter := func(g, m int, modify func(s *Share)) string {
+		s, err := ParseShare(mnemonics[g][m])
+		if err != nil {
+			t.Fatal(err)
+		}
+		modify(s)
+		return s.Mnemonic()
+	}
+
+	tests := []struct {
+		name      string
+		mnemonics []string
+		err       error
+	}{
+		{
+			name: "mismatched identifiers",
+			mnemonics: []string{
+				mnemonics[0][0],
+				alter(1, 0, func(s *Share) { s.Identifier ^= 1 }),
+				mnemonics[1][1],
+			},
+			err: ErrSharesMismatch,
+		},
+		{
+			name: "mismatched iteration exponents",
+			mnemonics: []string{
+				mnemonics[0][0],
+				alter(1, 0, func(s *Share) { s.IterationExponent++ }),
+				mnemonics[1][1],
+			},
+			err: ErrSharesMismatch,
+		},
+		{
+			name: "mismatched group thresholds",
+			mnemonics: []string{
+				mnemonics[0][0],
+				alter(1, 0, func(s *Share) { s.GroupThreshold = 1 }),
+				mnemonics[1][1],
+			},
+			err: ErrSharesMismatch,
+		},
+		{
+			name: "mismatched group counts",
+			mnemonics: []string{
+				mnemonics[0][0],
+				alter(1, 0, func(s *Share) { s.GroupCount = 4 }),
+				mnemonics[1][1],
+			},
+			err: ErrSharesMismatch,
+		},
+		{
+			name: "mismatched member thresholds",
+			mnemonics: []string{
+				mnemonics[0][0],
+				alter(1, 0, func(s *Share) { s.MemberThreshold = 3 }),
+				mnemonics[1][1],
+			},
+			err: ErrSharesMismatch,
+		},
+		{
+			name: "group threshold exceeds group count",
+			mnemonics: []string{
+				alter(0, 0, func(s *Share) { s.GroupThreshold = 4 }),
+			},
+			err: ErrThresholdExceedsCount,
+		},
+		{
+			name: "insufficient groups",
+			mnemonics: []string{
+				mnemonics[1][0],
+				mnemonics[1][2],
+			},
+			err: ErrNotEnoughGroups,
+		},
+		{
+			name: "insufficient members",
+			mnemonics: []string{
+				mnemonics[0][0],
+				mnemonics[2][0],
+				mnemonics[2][1],
+			},
+			err: ErrNotEnoughShares,
+		},
+		{
+			name: "duplicate member indices",
+			mnemonics: []string{
+				mnemonics[0][0],
+				mnemonics[1][0],
+				alter(1, 1, func(s *Share) { s.MemberIndex = 0 }),
+			},
+			err: ErrDuplicateShare,
+		},
+		{
+			name: "invalid digest",
+			mnemonics: []string{
+				mnemonics[0][0],
+				alter(1, 0, func(s *Share) { s.Value[0] ^= 1 }),
+				mnemonics[1][1],
+			},
+			err: ErrInvalidDigest,
+		},
+	}
+
+	for _, test := range tests {
+		_, err := CombineMnemonics(test.mnemonics, passphrase)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestSplitErrors(t *testing.T) {
+	masterSecret := []byte("ABCDEFGHIJKLMNOP")
+	tests := []struct {
+		name           string
+		groupThreshold int
+		groups         []Group
+		secret         []byte
+		passphrase     []byte
+		err            error
+	}{
+		{"short secret", 1, []Group{{1, 1}}, masterSecret[:14], nil, ErrInvalidMasterSecret},
+		{"odd secret", 1, []Group{{1, 1}}, append(masterSecret, 'Q'), nil, ErrInvalidMasterSecret},
+		{"non ascii passphrase", 1, []Group{{1, 1}}, masterSecret, []byte("caf\xc3\xa9"), ErrInvalidPassphrase},
+		{"group threshold exceeds count", 3, []Group{{1, 1}, {1, 1}}, masterSecret, nil, ErrThresholdExceedsCount},
+		{"member threshold exceeds count", 1, []Group{{3, 2}}, masterSecret, nil, ErrThresholdExceedsCount},
+		{"multiple shares with threshold one", 1, []Group{{1, 2}}, masterSecret, nil, ErrMultipleSharesThresholdOne},
+		{"too many shares", 1, []Group{{2, 17}}, masterSecret, nil, ErrTooManyShares},
+	}
+
+	for _, test := range tests {
+		_, err := SplitMasterSecret(test.groupThreshold, test.groups, test.secret, test.passphrase, false, 0)
+		if err != test.err {
+			t.Errorf("%v: expected error %v, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestParseShareErrors(t *testing.T) {
+	words := strings.Fields(vectors[0].mnemonics[0])
+
+	_, err := ParseShare(strings.Join(words[:19], " "))
+	if err != ErrInvalidMnemonicLength {
+		t.Errorf("expected %v, got %v", ErrInvalidMnemonicLength, err)
+	}
+
+	words[5] = "abandon"
+	_, err = ParseShare(strings.Join(words, " "))
+	if !errors.Is(err, ErrUnknownWord) {
+		t.Errorf("expected %v, got %v", ErrUnknownWord, err)
+	}
+}
+
+func TestMasterKey(t *testing.T) {
+	secret, err := CombineMnemonics(vectors[0].mnemonics, []byte("TREZOR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	net := &hdwallets.NetPrefix{
+		ExtPub:  []byte{0x1f, 0x74, 0x90, 0xf0},
+		ExtPriv: []byte{0x11, 0x24, 0xd9, 0x70},
+	}
+	if _, err := hdwallets.NewMaster(secret, net); err != nil {
+		t.Fatal(err)
+	}
+}